@@ -0,0 +1,61 @@
+package resolver
+
+import (
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/shortlink"
+)
+
+// AdminMutation resolves GraphQL mutations that require role.Admin,
+// unlocking operational workflows such as mass-disabling phishing
+// campaigns or migrating links between accounts without direct DB access.
+type AdminMutation struct {
+	authToken *authenticator.AuthToken
+	admin     shortlink.AdminManager
+}
+
+// BulkUpdateShortLinks applies args in order. When atomic is true, any
+// failure rolls back the whole batch; otherwise failed items are skipped
+// and the rest still apply.
+func (a AdminMutation) BulkUpdateShortLinks(args []UpdateShortLinkArgs, atomic bool) ([]*ShortLink, error) {
+	items := make([]shortlink.BulkUpdateItem, len(args))
+	for i, arg := range args {
+		items[i] = shortlink.BulkUpdateItem{
+			OldAlias: arg.OldAlias,
+			Update: shortlink.Update{
+				NewAlias:      arg.ShortLink.CustomAlias,
+				NewLongLink:   arg.ShortLink.LongLink,
+				AddAliases:    arg.AddAliases,
+				RemoveAliases: arg.RemoveAliases,
+			},
+		}
+	}
+
+	updated, err := a.admin.BulkUpdateShortLinks(a.authToken.User().ID, items, atomic)
+	if err != nil {
+		return nil, err
+	}
+
+	shortLinks := make([]*ShortLink, len(updated))
+	for i, shortLink := range updated {
+		shortLinks[i] = &ShortLink{shortLink: shortLink}
+	}
+	return shortLinks, nil
+}
+
+// TransferShortLink re-assigns alias from fromUserID to toUserID.
+func (a AdminMutation) TransferShortLink(alias string, fromUserID string, toUserID string) error {
+	toUser := entity.User{ID: toUserID}
+	return a.admin.TransferShortLink(a.authToken.User().ID, alias, fromUserID, toUser)
+}
+
+// DisableShortLink marks alias as disabled, recording reason.
+func (a AdminMutation) DisableShortLink(alias string, reason string) error {
+	return a.admin.DisableShortLink(a.authToken.User().ID, alias, reason)
+}
+
+// newAdminMutation creates an AdminMutation for the user identified by
+// authToken.
+func newAdminMutation(authToken *authenticator.AuthToken, admin shortlink.AdminManager) AdminMutation {
+	return AdminMutation{authToken: authToken, admin: admin}
+}
@@ -0,0 +1,175 @@
+// +build !integration all
+
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/app/fw/crypto"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/authorizer"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/changelog"
+	"github.com/short-d/short/backend/app/usecase/keygen"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/risk"
+	"github.com/short-d/short/backend/app/usecase/shortlink"
+	"github.com/short-d/short/backend/app/usecase/validator"
+)
+
+func newAdminManagerForTest(t *testing.T, roles map[string][]role.Role, shortLinks map[string]entity.ShortLink) (shortlink.AdminManager, *repository.ShortLinkAliasFake, *repository.ChangeLogFake, authenticator.AuthToken) {
+	now := time.Now().UTC()
+
+	shortLinkRepo := repository.NewShortLinkFake(shortLinks)
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+	longLinkValidator := validator.NewLongLink()
+	aliasValidator := validator.NewCustomAlias()
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+	riskDetector := risk.NewDetector(blacklist)
+	tm := timer.NewStub(now)
+
+	keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+	keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+	assert.Equal(t, nil, err)
+
+	changeLogRepo := repository.NewChangeLogFake([]entity.Change{})
+	userChangeLogRepo := repository.NewUserChangeLogFake(map[string]time.Time{})
+	fakeRolesRepo := repository.NewUserRoleFake(roles)
+	rb := rbac.NewRBAC(fakeRolesRepo)
+	au := authorizer.NewAuthorizer(rb)
+	changeLog := changelog.NewPersist(keyGen, tm, &changeLogRepo, &userChangeLogRepo, au)
+
+	updater := shortlink.NewUpdaterPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		longLinkValidator,
+		aliasValidator,
+		tm,
+		riskDetector,
+	)
+	admin := shortlink.NewAdminManager(&shortLinkRepo, &shortLinkAliasRepo, &userShortLinkRepo, updater, au, changeLog)
+
+	tokenizer := crypto.NewTokenizerFake()
+	auth := authenticator.NewAuthenticator(tokenizer, tm, time.Hour)
+	authToken, err := auth.GenerateToken(entity.User{ID: "1", Email: "admin@short-d.com"})
+	assert.Equal(t, nil, err)
+
+	return admin, &shortLinkAliasRepo, &changeLogRepo, authToken
+}
+
+func TestAdminMutation_BulkUpdateShortLinks(t *testing.T) {
+	t.Parallel()
+	newLongLink := "https://www.short-d.com"
+
+	testCases := []struct {
+		name           string
+		requesterRoles map[string][]role.Role
+		atomic         bool
+		args           []UpdateShortLinkArgs
+		expectedCount  int
+		hasError       bool
+	}{
+		{
+			name:           "non-admin is rejected",
+			requesterRoles: map[string][]role.Role{},
+			atomic:         false,
+			args: []UpdateShortLinkArgs{
+				{OldAlias: "AliasOne", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+			},
+			hasError: true,
+		},
+		{
+			name:           "best effort applies the items that succeed",
+			requesterRoles: map[string][]role.Role{"1": {role.Admin}},
+			atomic:         false,
+			args: []UpdateShortLinkArgs{
+				{OldAlias: "AliasOne", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+				{OldAlias: "DoesNotExist", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+			},
+			expectedCount: 1,
+			hasError:      false,
+		},
+		{
+			name:           "atomic mode fails the whole batch together",
+			requesterRoles: map[string][]role.Role{"1": {role.Admin}},
+			atomic:         true,
+			args: []UpdateShortLinkArgs{
+				{OldAlias: "AliasOne", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+				{OldAlias: "DoesNotExist", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+			},
+			hasError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			shortLinks := map[string]entity.ShortLink{
+				"AliasOne": {Alias: "AliasOne", LongLink: "https://www.google.com/"},
+			}
+			admin, _, changeLogRepo, authToken := newAdminManagerForTest(t, testCase.requesterRoles, shortLinks)
+			adminMutation := newAdminMutation(&authToken, admin)
+			updated, err := adminMutation.BulkUpdateShortLinks(testCase.args, testCase.atomic)
+			if testCase.hasError {
+				assert.NotEqual(t, nil, err)
+				return
+			}
+			assert.Equal(t, nil, err)
+			assert.Equal(t, testCase.expectedCount, len(updated))
+
+			changes, err := changeLogRepo.GetChanges()
+			assert.Equal(t, nil, err)
+			assert.Equal(t, testCase.expectedCount, len(changes))
+		})
+	}
+}
+
+// TestAdminMutation_BulkUpdateShortLinks_AtomicRollback verifies that an
+// atomic batch failing partway through undoes every side effect of the
+// items that had already succeeded: secondary aliases added, secondary
+// aliases removed, and the changelog entries written for them.
+func TestAdminMutation_BulkUpdateShortLinks_AtomicRollback(t *testing.T) {
+	t.Parallel()
+	newLongLink := "https://www.short-d.com"
+
+	shortLinks := map[string]entity.ShortLink{
+		"AliasOne": {ID: "canonical-1", Alias: "AliasOne", LongLink: "https://www.google.com/"},
+	}
+	admin, shortLinkAliasRepo, changeLogRepo, authToken := newAdminManagerForTest(
+		t,
+		map[string][]role.Role{"1": {role.Admin}},
+		shortLinks,
+	)
+	err := shortLinkAliasRepo.CreateAlias(entity.ShortLinkAlias{Alias: "LegacyAlias", CanonicalID: "canonical-1"})
+	assert.Equal(t, nil, err)
+
+	adminMutation := newAdminMutation(&authToken, admin)
+	_, err = adminMutation.BulkUpdateShortLinks([]UpdateShortLinkArgs{
+		{
+			OldAlias:      "AliasOne",
+			ShortLink:     ShortLinkInput{LongLink: &newLongLink},
+			AddAliases:    []string{"VanityAlias"},
+			RemoveAliases: []string{"LegacyAlias"},
+		},
+		{OldAlias: "DoesNotExist", ShortLink: ShortLinkInput{LongLink: &newLongLink}},
+	}, true)
+	assert.NotEqual(t, nil, err)
+
+	isExist, err := shortLinkAliasRepo.IsAliasExist("VanityAlias")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, isExist)
+
+	isExist, err = shortLinkAliasRepo.IsAliasExist("LegacyAlias")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, isExist)
+
+	changes, err := changeLogRepo.GetChanges()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(changes))
+}
@@ -0,0 +1,175 @@
+package resolver
+
+import (
+	"errors"
+
+	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/authenticator/mfa"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/changelog"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/shortlink"
+)
+
+// authMutation resolves GraphQL mutations that require an authenticated
+// caller: either a human user's AuthToken or a machine-to-machine
+// ScopedToken exchanged through approle.Exchanger.
+type authMutation struct {
+	authToken      *authenticator.AuthToken
+	scopedToken    *authenticator.ScopedToken
+	auth           authenticator.Authenticator
+	changeLog      changelog.Persist
+	creator        shortlink.CreatorPersist
+	updater        shortlink.UpdaterPersist
+	shortLinkRepo  repository.ShortLinkRepo
+	credentialRepo repository.APICredentialRepo
+	mfaPolicy      shortlink.ShortLinkMFAPolicy
+	mfaChallenger  mfa.Challenger
+}
+
+// actorID identifies who is performing the mutation, for the changelog.
+func (a authMutation) actorID() string {
+	if a.scopedToken != nil {
+		return a.scopedToken.RoleID()
+	}
+	return a.authToken.User().ID
+}
+
+// UpdateShortLink updates the short link known by args.OldAlias. It
+// returns a nil ShortLink without error when args requests no change. A
+// ScopedToken caller must additionally have an APICredential that has not
+// since been revoked, have been granted role.Admin, and hold a
+// "shortlink:update:<alias>" ACL covering args.OldAlias. A human
+// caller updating a short link flagged as high-value by mfaPolicy must
+// first complete an MFA challenge: the first call returns
+// MFARequiredError, and the client re-invokes the mutation with
+// ChallengeID and MFAResponse set.
+func (a authMutation) UpdateShortLink(args *UpdateShortLinkArgs) (*ShortLink, error) {
+	if args.ShortLink.CustomAlias == nil &&
+		args.ShortLink.LongLink == nil &&
+		len(args.AddAliases) == 0 &&
+		len(args.RemoveAliases) == 0 {
+		return nil, nil
+	}
+
+	if a.scopedToken != nil {
+		credential, err := a.credentialRepo.GetCredential(a.scopedToken.RoleID())
+		if err != nil {
+			return nil, err
+		}
+		if credential.Revoked {
+			return nil, errors.New("API credential backing this scoped token has been revoked")
+		}
+		if !a.scopedToken.HasRole(role.Admin) {
+			return nil, errors.New("scoped token is not granted a role permitted to update short links")
+		}
+		if !a.scopedToken.HasAliasScope("shortlink", "update", args.OldAlias) {
+			return nil, errors.New("scoped token is not permitted to update this alias")
+		}
+	}
+
+	if a.authToken != nil {
+		err := a.enforceMFA(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	update := shortlink.Update{
+		NewAlias:      args.ShortLink.CustomAlias,
+		NewLongLink:   args.ShortLink.LongLink,
+		AddAliases:    args.AddAliases,
+		RemoveAliases: args.RemoveAliases,
+	}
+	updatedShortLink, err := a.updater.UpdateShortLink(args.OldAlias, update)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.changeLog.Log(a.actorID(), "updated short link "+args.OldAlias)
+	if err != nil {
+		return nil, err
+	}
+	return &ShortLink{shortLink: updatedShortLink}, nil
+}
+
+// enforceMFA returns nil once args carries a verified MFA response for a
+// high-value short link, or when the short link is not high-value to
+// begin with. An error from looking up the short link or from the
+// challenger's Verify or Challenge is returned as is. Otherwise it issues
+// a fresh challenge and returns it as an MFARequiredError, without
+// mutating anything.
+func (a authMutation) enforceMFA(args *UpdateShortLinkArgs) error {
+	shortLink, err := a.shortLinkRepo.GetShortLinkByAlias(args.OldAlias)
+	if err != nil {
+		return err
+	}
+	if !a.mfaPolicy.RequiresMFA(shortLink) {
+		return nil
+	}
+
+	if args.ChallengeID != nil && args.MFAResponse != nil {
+		verified, err := a.mfaChallenger.Verify(*args.ChallengeID, *args.MFAResponse)
+		if err != nil {
+			return err
+		}
+		if verified {
+			return nil
+		}
+	}
+
+	challengeID, err := a.mfaChallenger.Challenge(a.authToken.User())
+	if err != nil {
+		return err
+	}
+	return MFARequiredError{ChallengeID: challengeID}
+}
+
+// newAuthMutation creates an authMutation for the user identified by
+// authToken.
+func newAuthMutation(
+	authToken *authenticator.AuthToken,
+	auth authenticator.Authenticator,
+	changeLog changelog.Persist,
+	creator shortlink.CreatorPersist,
+	updater shortlink.UpdaterPersist,
+	shortLinkRepo repository.ShortLinkRepo,
+	mfaPolicy shortlink.ShortLinkMFAPolicy,
+	mfaChallenger mfa.Challenger,
+) authMutation {
+	return authMutation{
+		authToken:     authToken,
+		auth:          auth,
+		changeLog:     changeLog,
+		creator:       creator,
+		updater:       updater,
+		shortLinkRepo: shortLinkRepo,
+		mfaPolicy:     mfaPolicy,
+		mfaChallenger: mfaChallenger,
+	}
+}
+
+// newAuthMutationWithScopedToken creates an authMutation for the
+// machine-to-machine caller identified by scopedToken. Scoped tokens are
+// never subject to interactive MFA. Because a ScopedToken is a stateless
+// signed blob, credentialRepo is consulted on every mutation to make sure
+// the APICredential it was minted from has not since been revoked.
+func newAuthMutationWithScopedToken(
+	scopedToken *authenticator.ScopedToken,
+	auth authenticator.Authenticator,
+	changeLog changelog.Persist,
+	creator shortlink.CreatorPersist,
+	updater shortlink.UpdaterPersist,
+	shortLinkRepo repository.ShortLinkRepo,
+	credentialRepo repository.APICredentialRepo,
+) authMutation {
+	return authMutation{
+		scopedToken:    scopedToken,
+		auth:           auth,
+		changeLog:      changeLog,
+		creator:        creator,
+		updater:        updater,
+		shortLinkRepo:  shortLinkRepo,
+		credentialRepo: credentialRepo,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/short-d/app/fw/timer"
 	"github.com/short-d/short/backend/app/entity"
 	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/authenticator/mfa"
 	"github.com/short-d/short/backend/app/usecase/authorizer"
 	"github.com/short-d/short/backend/app/usecase/authorizer/rbac"
 	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
@@ -41,6 +42,7 @@ func TestUpdateShortLink(t *testing.T) {
 		shortLinks         shortLinkMap
 		relationUsers      []entity.User
 		relationShortLinks []entity.ShortLink
+		aliasSeed          map[string]entity.ShortLinkAlias
 		expectedShortLink  *ShortLink
 		hasError           bool
 	}{
@@ -70,6 +72,129 @@ func TestUpdateShortLink(t *testing.T) {
 			expectedShortLink: nil,
 			hasError:          false,
 		},
+		{
+			name: "add secondary alias",
+			args: &UpdateShortLinkArgs{
+				OldAlias:   "SimpleAlias",
+				ShortLink:  ShortLinkInput{},
+				AddAliases: []string{"VanityAlias"},
+			},
+			user: entity.User{
+				ID:    "1",
+				Email: "short@gmail.com",
+			},
+			shortLinks: shortLinks,
+			relationUsers: []entity.User{
+				{
+					ID:    "1",
+					Email: "short@gmail.com",
+				},
+			},
+			relationShortLinks: []entity.ShortLink{
+				{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			expectedShortLink: &ShortLink{
+				shortLink: entity.ShortLink{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			hasError: false,
+		},
+		{
+			name: "add secondary alias colliding with an existing canonical alias",
+			args: &UpdateShortLinkArgs{
+				OldAlias:   "SimpleAlias",
+				ShortLink:  ShortLinkInput{},
+				AddAliases: []string{"SimpleAlias"},
+			},
+			user: entity.User{
+				ID:    "1",
+				Email: "short@gmail.com",
+			},
+			shortLinks: shortLinks,
+			relationUsers: []entity.User{
+				{
+					ID:    "1",
+					Email: "short@gmail.com",
+				},
+			},
+			relationShortLinks: []entity.ShortLink{
+				{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			hasError: true,
+		},
+		{
+			name: "rename canonical alias colliding with an existing secondary alias",
+			args: &UpdateShortLinkArgs{
+				OldAlias: "SimpleAlias",
+				ShortLink: ShortLinkInput{
+					CustomAlias: &newAlias,
+				},
+			},
+			user: entity.User{
+				ID:    "1",
+				Email: "short@gmail.com",
+			},
+			shortLinks: shortLinks,
+			relationUsers: []entity.User{
+				{
+					ID:    "1",
+					Email: "short@gmail.com",
+				},
+			},
+			relationShortLinks: []entity.ShortLink{
+				{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			aliasSeed: map[string]entity.ShortLinkAlias{
+				"NewAlias": {Alias: "NewAlias", CanonicalID: "other-id"},
+			},
+			hasError: true,
+		},
+		{
+			name: "remove secondary alias",
+			args: &UpdateShortLinkArgs{
+				OldAlias:      "SimpleAlias",
+				ShortLink:     ShortLinkInput{},
+				RemoveAliases: []string{"VanityAlias"},
+			},
+			user: entity.User{
+				ID:    "1",
+				Email: "short@gmail.com",
+			},
+			shortLinks: shortLinks,
+			relationUsers: []entity.User{
+				{
+					ID:    "1",
+					Email: "short@gmail.com",
+				},
+			},
+			relationShortLinks: []entity.ShortLink{
+				{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			aliasSeed: map[string]entity.ShortLinkAlias{
+				"VanityAlias": {Alias: "VanityAlias", CanonicalID: "simple-id"},
+			},
+			expectedShortLink: &ShortLink{
+				shortLink: entity.ShortLink{
+					Alias:    "SimpleAlias",
+					LongLink: "https://www.google.com/",
+				},
+			},
+			hasError: false,
+		},
 		{
 			name: "update only alias",
 			args: &UpdateShortLinkArgs{
@@ -206,6 +331,7 @@ func TestUpdateShortLink(t *testing.T) {
 			}
 			blacklist := risk.NewBlackListFake(blockedHash)
 			shortLinkRepo := repository.NewShortLinkFake(testCase.shortLinks)
+			shortLinkAliasRepo := repository.NewShortLinkAliasFake(testCase.aliasSeed)
 			userShortLinkRepo := repository.NewUserShortLinkRepoFake(
 				testCase.relationUsers,
 				testCase.relationShortLinks,
@@ -237,6 +363,7 @@ func TestUpdateShortLink(t *testing.T) {
 
 			creator := shortlink.NewCreatorPersist(
 				&shortLinkRepo,
+				&shortLinkAliasRepo,
 				&userShortLinkRepo,
 				keyGen,
 				longLinkValidator,
@@ -246,18 +373,24 @@ func TestUpdateShortLink(t *testing.T) {
 			)
 			updater := shortlink.NewUpdaterPersist(
 				&shortLinkRepo,
+				&shortLinkAliasRepo,
 				&userShortLinkRepo,
 				longLinkValidator,
 				aliasValidator,
 				tm,
 				riskDetector,
 			)
+			mfaPolicy := shortlink.NewShortLinkMFAPolicy(0, 0, tm)
+			mfaChallenger := mfa.NewTOTPChallenger(tm, time.Minute)
 			authMutation := newAuthMutation(
 				&authToken,
 				auth,
 				changeLog,
 				creator,
 				updater,
+				&shortLinkRepo,
+				mfaPolicy,
+				mfaChallenger,
 			)
 			shortLink, err := authMutation.UpdateShortLink(testCase.args)
 			if testCase.hasError {
@@ -273,4 +406,273 @@ func TestUpdateShortLink(t *testing.T) {
 			assert.Equal(t, true, shortLink.shortLink.UpdatedAt.After(now))
 		})
 	}
+}
+
+func TestAuthMutation_UpdateShortLink_ScopedToken(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	newLongLink := "https://www.short-d.com"
+
+	testCases := []struct {
+		name      string
+		roles     []role.Role
+		aliasACLs []string
+		revoked   bool
+		hasError  bool
+	}{
+		{
+			name:      "scoped token with role.Admin and a matching alias ACL can update",
+			roles:     []role.Role{role.Admin},
+			aliasACLs: []string{"shortlink:update:SimpleAlias"},
+			hasError:  false,
+		},
+		{
+			name:      "scoped token without a matching alias ACL is rejected",
+			roles:     []role.Role{role.Admin},
+			aliasACLs: []string{"shortlink:update:SomeOtherAlias"},
+			hasError:  true,
+		},
+		{
+			name:      "scoped token with a matching alias ACL but no granted role is rejected",
+			roles:     nil,
+			aliasACLs: []string{"shortlink:update:SimpleAlias"},
+			hasError:  true,
+		},
+		{
+			name:      "scoped token backed by a revoked SecretID is rejected even with role.Admin and a matching alias ACL",
+			roles:     []role.Role{role.Admin},
+			aliasACLs: []string{"shortlink:update:SimpleAlias"},
+			revoked:   true,
+			hasError:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{
+				"SimpleAlias": {Alias: "SimpleAlias", LongLink: "https://www.google.com/"},
+			})
+			shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{})
+			userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+
+			keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+			keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+			assert.Equal(t, nil, err)
+
+			longLinkValidator := validator.NewLongLink()
+			aliasValidator := validator.NewCustomAlias()
+			blacklist := risk.NewBlackListFake(map[string]bool{})
+			riskDetector := risk.NewDetector(blacklist)
+
+			tm := timer.NewStub(now)
+			changeLogRepo := repository.NewChangeLogFake([]entity.Change{})
+			userChangeLogRepo := repository.NewUserChangeLogFake(map[string]time.Time{})
+			fakeRolesRepo := repository.NewUserRoleFake(map[string][]role.Role{})
+			rb := rbac.NewRBAC(fakeRolesRepo)
+			au := authorizer.NewAuthorizer(rb)
+			changeLog := changelog.NewPersist(keyGen, tm, &changeLogRepo, &userChangeLogRepo, au)
+
+			tokenizer := crypto.NewTokenizerFake()
+			auth := authenticator.NewAuthenticator(tokenizer, tm, time.Hour)
+			scopedToken, err := auth.GenerateScopedToken("role-1", testCase.roles, testCase.aliasACLs, 5*time.Minute)
+			assert.Equal(t, nil, err)
+
+			credentialRepo := repository.NewAPICredentialFake(map[string]entity.APICredential{
+				"role-1": {RoleID: "role-1", Roles: testCase.roles, AliasACLs: testCase.aliasACLs, Revoked: testCase.revoked},
+			})
+
+			creator := shortlink.NewCreatorPersist(
+				&shortLinkRepo,
+				&shortLinkAliasRepo,
+				&userShortLinkRepo,
+				keyGen,
+				longLinkValidator,
+				aliasValidator,
+				tm,
+				riskDetector,
+			)
+			updater := shortlink.NewUpdaterPersist(
+				&shortLinkRepo,
+				&shortLinkAliasRepo,
+				&userShortLinkRepo,
+				longLinkValidator,
+				aliasValidator,
+				tm,
+				riskDetector,
+			)
+			authMutation := newAuthMutationWithScopedToken(&scopedToken, auth, changeLog, creator, updater, &shortLinkRepo, &credentialRepo)
+			_, err = authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+				OldAlias:  "SimpleAlias",
+				ShortLink: ShortLinkInput{LongLink: &newLongLink},
+			})
+			if testCase.hasError {
+				assert.NotEqual(t, nil, err)
+				return
+			}
+			assert.Equal(t, nil, err)
+		})
+	}
+}
+
+// newAuthMutationForMFATest builds an authMutation whose mfaPolicy flags a
+// short link with at least clickThreshold clicks, using challenger as the
+// MFA challenger.
+func newAuthMutationForMFATest(
+	t *testing.T,
+	now time.Time,
+	shortLink entity.ShortLink,
+	clickThreshold int64,
+	challenger mfa.Challenger,
+) (authMutation, *repository.ShortLinkFake, *repository.ChangeLogFake) {
+	user := entity.User{ID: "1", Email: "short@gmail.com"}
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{shortLink.Alias: shortLink})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(
+		[]entity.User{user},
+		[]entity.ShortLink{shortLink},
+	)
+
+	keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+	keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+	assert.Equal(t, nil, err)
+
+	longLinkValidator := validator.NewLongLink()
+	aliasValidator := validator.NewCustomAlias()
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+	riskDetector := risk.NewDetector(blacklist)
+
+	tm := timer.NewStub(now)
+	changeLogRepo := repository.NewChangeLogFake([]entity.Change{})
+	userChangeLogRepo := repository.NewUserChangeLogFake(map[string]time.Time{})
+	fakeRolesRepo := repository.NewUserRoleFake(map[string][]role.Role{})
+	rb := rbac.NewRBAC(fakeRolesRepo)
+	au := authorizer.NewAuthorizer(rb)
+	changeLog := changelog.NewPersist(keyGen, tm, &changeLogRepo, &userChangeLogRepo, au)
+
+	tokenizer := crypto.NewTokenizerFake()
+	auth := authenticator.NewAuthenticator(tokenizer, tm, time.Hour)
+	authToken, err := auth.GenerateToken(user)
+	assert.Equal(t, nil, err)
+
+	creator := shortlink.NewCreatorPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		keyGen,
+		longLinkValidator,
+		aliasValidator,
+		tm,
+		riskDetector,
+	)
+	updater := shortlink.NewUpdaterPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		longLinkValidator,
+		aliasValidator,
+		tm,
+		riskDetector,
+	)
+	mfaPolicy := shortlink.NewShortLinkMFAPolicy(0, clickThreshold, tm)
+	authMutation := newAuthMutation(
+		&authToken,
+		auth,
+		changeLog,
+		creator,
+		updater,
+		&shortLinkRepo,
+		mfaPolicy,
+		challenger,
+	)
+	return authMutation, &shortLinkRepo, &changeLogRepo
+}
+
+func TestAuthMutation_UpdateShortLink_MFA(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	newLongLink := "https://www.short-d.com"
+
+	t.Run("fresh short link does not require MFA", func(t *testing.T) {
+		t.Parallel()
+		shortLink := entity.ShortLink{Alias: "SimpleAlias", LongLink: "https://www.google.com/", ClickCount: 0}
+		challenger := mfa.NewChallengerFake("challenge-1", "123456")
+		authMutation, _, changeLogRepo := newAuthMutationForMFATest(t, now, shortLink, 1000, challenger)
+
+		_, err := authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+			OldAlias:  "SimpleAlias",
+			ShortLink: ShortLinkInput{LongLink: &newLongLink},
+		})
+		assert.Equal(t, nil, err)
+
+		changes, err := changeLogRepo.GetChanges()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(changes))
+	})
+
+	t.Run("high-value short link requires MFA and accepts the right code", func(t *testing.T) {
+		t.Parallel()
+		shortLink := entity.ShortLink{Alias: "SimpleAlias", LongLink: "https://www.google.com/", ClickCount: 5000}
+		challenger := mfa.NewChallengerFake("challenge-1", "123456")
+		authMutation, shortLinkRepo, changeLogRepo := newAuthMutationForMFATest(t, now, shortLink, 1000, challenger)
+
+		_, err := authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+			OldAlias:  "SimpleAlias",
+			ShortLink: ShortLinkInput{LongLink: &newLongLink},
+		})
+		mfaErr, ok := err.(MFARequiredError)
+		assert.Equal(t, true, ok)
+
+		challengeID := mfaErr.ChallengeID
+		validResponse := "123456"
+		_, err = authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+			OldAlias:    "SimpleAlias",
+			ShortLink:   ShortLinkInput{LongLink: &newLongLink},
+			ChallengeID: &challengeID,
+			MFAResponse: &validResponse,
+		})
+		assert.Equal(t, nil, err)
+
+		updated, err := shortLinkRepo.GetShortLinkByAlias("SimpleAlias")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, newLongLink, updated.LongLink)
+
+		changes, err := changeLogRepo.GetChanges()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(changes))
+	})
+
+	t.Run("high-value short link rejects the wrong code without persisting", func(t *testing.T) {
+		t.Parallel()
+		shortLink := entity.ShortLink{Alias: "SimpleAlias", LongLink: "https://www.google.com/", ClickCount: 5000}
+		challenger := mfa.NewChallengerFake("challenge-1", "123456")
+		authMutation, shortLinkRepo, changeLogRepo := newAuthMutationForMFATest(t, now, shortLink, 1000, challenger)
+
+		_, err := authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+			OldAlias:  "SimpleAlias",
+			ShortLink: ShortLinkInput{LongLink: &newLongLink},
+		})
+		mfaErr, ok := err.(MFARequiredError)
+		assert.Equal(t, true, ok)
+
+		challengeID := mfaErr.ChallengeID
+		wrongResponse := "000000"
+		_, err = authMutation.UpdateShortLink(&UpdateShortLinkArgs{
+			OldAlias:    "SimpleAlias",
+			ShortLink:   ShortLinkInput{LongLink: &newLongLink},
+			ChallengeID: &challengeID,
+			MFAResponse: &wrongResponse,
+		})
+		assert.NotEqual(t, nil, err)
+		if _, ok := err.(MFARequiredError); !ok {
+			t.Fatalf("expected a fresh MFARequiredError, got %v", err)
+		}
+
+		updated, err := shortLinkRepo.GetShortLinkByAlias("SimpleAlias")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "https://www.google.com/", updated.LongLink)
+
+		changes, err := changeLogRepo.GetChanges()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 0, len(changes))
+	})
 }
\ No newline at end of file
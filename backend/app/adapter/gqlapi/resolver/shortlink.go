@@ -0,0 +1,57 @@
+package resolver
+
+import (
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// shortLinkMap indexes short links by alias, used to seed fakes in tests.
+type shortLinkMap map[string]entity.ShortLink
+
+// ShortLink is the GraphQL representation of a short link.
+type ShortLink struct {
+	shortLink entity.ShortLink
+}
+
+// Alias resolves the short link's current canonical alias.
+func (s ShortLink) Alias() string {
+	return s.shortLink.Alias
+}
+
+// LongLink resolves the short link's destination URL.
+func (s ShortLink) LongLink() string {
+	return s.shortLink.LongLink
+}
+
+// ShortLinkInput carries the fields of a short link a client wants to
+// create or update. Nil fields are left unchanged.
+type ShortLinkInput struct {
+	CustomAlias *string
+	LongLink    *string
+}
+
+// UpdateShortLinkArgs carries the arguments of the updateShortLink GraphQL
+// mutation. AddAliases and RemoveAliases manage secondary aliases that
+// resolve to the same canonical short link without affecting OldAlias.
+// ChallengeID and MFAResponse carry a client's response to a prior
+// MFARequiredError, re-invoking the same mutation.
+type UpdateShortLinkArgs struct {
+	OldAlias      string
+	ShortLink     ShortLinkInput
+	AddAliases    []string
+	RemoveAliases []string
+	ChallengeID   *string
+	MFAResponse   *string
+}
+
+// MFARequiredError is returned when a mutation targets a high-value short
+// link and the caller has not yet completed an MFA challenge for it. The
+// client re-invokes the mutation with UpdateShortLinkArgs.ChallengeID and
+// UpdateShortLinkArgs.MFAResponse set.
+type MFARequiredError struct {
+	ChallengeID string
+}
+
+// Error implements the error interface.
+func (e MFARequiredError) Error() string {
+	return "MFA verification is required to update this short link"
+}
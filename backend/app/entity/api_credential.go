@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+)
+
+// APICredential is a machine-to-machine RoleID/SecretID pair, modeled on
+// AppRole, used to mint short-lived ScopedTokens for the GraphQL mutation
+// surface without a human user behind them.
+type APICredential struct {
+	RoleID       string
+	SecretIDHash string
+	Roles        []role.Role
+	AliasACLs    []string
+	Revoked      bool
+	CreatedAt    time.Time
+}
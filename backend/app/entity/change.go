@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// Change records a single user-visible change to an entity, surfaced to
+// affected users through the product changelog.
+type Change struct {
+	ID        string
+	Summary   string
+	UserID    string
+	CreatedAt time.Time
+}
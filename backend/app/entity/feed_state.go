@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// FeedState tracks how far a threat-intel Feed has been synced, so a
+// restart can resume incrementally instead of re-pulling everything.
+type FeedState struct {
+	FeedName string
+	Cursor   string
+	SyncedAt time.Time
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// ShortLink represents a canonical short link created by a user. Its Alias
+// is the primary, user-facing alias; secondary aliases that also resolve to
+// it are tracked separately as ShortLinkAlias rows keyed by CanonicalID.
+type ShortLink struct {
+	ID             string
+	Alias          string
+	LongLink       string
+	ExpireAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Disabled       bool
+	DisabledReason string
+	ClickCount     int64
+}
+
+// ShortLinkAlias maps a secondary alias to the canonical short link it
+// resolves to, allowing a link to be renamed or re-targeted without
+// breaking vanity or legacy aliases that already point at it.
+type ShortLinkAlias struct {
+	Alias       string
+	CanonicalID string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,10 @@
+package entity
+
+// User represents a person who can create and manage short links.
+type User struct {
+	ID    string
+	Email string
+	// MFASecretKey is the user's TOTP secret, set once they enroll in
+	// multi-factor authentication. Empty when MFA is not enabled.
+	MFASecretKey string
+}
@@ -0,0 +1,145 @@
+// Package approle implements an AppRole-style machine-to-machine
+// credential exchange: a long-lived RoleID paired with a rotatable
+// SecretID is traded for a short-lived authenticator.ScopedToken.
+package approle
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/changelog"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// Exchanger trades a valid RoleID/SecretID pair for a ScopedToken.
+type Exchanger struct {
+	credentialRepo repository.APICredentialRepo
+	auth           authenticator.Authenticator
+	changeLog      changelog.Persist
+	tokenTTL       time.Duration
+}
+
+// Exchange verifies roleID and secretID against the stored credential and,
+// if the credential is neither unknown nor revoked, mints a ScopedToken
+// carrying the credential's roles and alias ACLs. A successful exchange is
+// recorded in the changelog, attributed to roleID.
+func (e Exchanger) Exchange(roleID string, secretID string) (authenticator.ScopedToken, error) {
+	credential, err := e.credentialRepo.GetCredential(roleID)
+	if err != nil {
+		return authenticator.ScopedToken{}, err
+	}
+	if credential.Revoked {
+		return authenticator.ScopedToken{}, errors.New("API credential has been revoked")
+	}
+	if !isSecretIDValid(credential.SecretIDHash, secretID) {
+		return authenticator.ScopedToken{}, errors.New("SecretID is invalid")
+	}
+	scopedToken, err := e.auth.GenerateScopedToken(roleID, credential.Roles, credential.AliasACLs, e.tokenTTL)
+	if err != nil {
+		return authenticator.ScopedToken{}, err
+	}
+	_, err = e.changeLog.Log(roleID, "exchanged SecretID for a scoped token")
+	if err != nil {
+		return authenticator.ScopedToken{}, err
+	}
+	return scopedToken, nil
+}
+
+// RotateSecretID replaces roleID's SecretID with a freshly generated one,
+// invalidating the old one, and returns the new SecretID in plaintext.
+// Callers must persist it securely; only its hash is stored. The rotation
+// is recorded in the changelog, attributed to roleID.
+func (e Exchanger) RotateSecretID(roleID string) (string, error) {
+	credential, err := e.credentialRepo.GetCredential(roleID)
+	if err != nil {
+		return "", err
+	}
+
+	secretID, err := GenerateSecretID()
+	if err != nil {
+		return "", err
+	}
+	credential.SecretIDHash = hashSecretID(secretID)
+
+	err = e.credentialRepo.UpdateCredential(credential)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = e.changeLog.Log(roleID, "rotated SecretID")
+	if err != nil {
+		return "", err
+	}
+	return secretID, nil
+}
+
+// Revoke permanently disables roleID, rejecting any future Exchange call
+// for it. The revocation is recorded in the changelog, attributed to
+// roleID.
+func (e Exchanger) Revoke(roleID string) error {
+	credential, err := e.credentialRepo.GetCredential(roleID)
+	if err != nil {
+		return err
+	}
+	credential.Revoked = true
+	err = e.credentialRepo.UpdateCredential(credential)
+	if err != nil {
+		return err
+	}
+	_, err = e.changeLog.Log(roleID, "revoked API credential")
+	return err
+}
+
+// NewExchanger creates an Exchanger backed by credentialRepo, minting
+// tokens via auth that are valid for tokenTTL and logging every exchange,
+// rotation, and revocation through changeLog.
+func NewExchanger(credentialRepo repository.APICredentialRepo, auth authenticator.Authenticator, changeLog changelog.Persist, tokenTTL time.Duration) Exchanger {
+	return Exchanger{credentialRepo: credentialRepo, auth: auth, changeLog: changeLog, tokenTTL: tokenTTL}
+}
+
+// GenerateRoleID creates a new, random RoleID.
+func GenerateRoleID() (string, error) {
+	return randomHex(16)
+}
+
+// GenerateSecretID creates a new, random SecretID.
+func GenerateSecretID() (string, error) {
+	return randomHex(32)
+}
+
+// NewCredential creates an APICredential for roleID, hashing secretID so
+// the plaintext is never persisted.
+func NewCredential(roleID string, secretID string, roles []role.Role, aliasACLs []string, createdAt time.Time) entity.APICredential {
+	return entity.APICredential{
+		RoleID:       roleID,
+		SecretIDHash: hashSecretID(secretID),
+		Roles:        roles,
+		AliasACLs:    aliasACLs,
+		CreatedAt:    createdAt,
+	}
+}
+
+func hashSecretID(secretID string) string {
+	sum := sha256.Sum256([]byte(secretID))
+	return hex.EncodeToString(sum[:])
+}
+
+func isSecretIDValid(storedHash string, candidate string) bool {
+	candidateHash := hashSecretID(candidate)
+	return subtle.ConstantTimeCompare([]byte(storedHash), []byte(candidateHash)) == 1
+}
+
+func randomHex(numBytes int) (string, error) {
+	buf := make([]byte, numBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,147 @@
+// +build !integration all
+
+package approle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/app/fw/crypto"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authenticator"
+	"github.com/short-d/short/backend/app/usecase/authorizer"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/changelog"
+	"github.com/short-d/short/backend/app/usecase/keygen"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+func newChangeLogForTest(t *testing.T, now time.Time) (changelog.Persist, *repository.ChangeLogFake) {
+	changeLogRepo := repository.NewChangeLogFake([]entity.Change{})
+	userChangeLogRepo := repository.NewUserChangeLogFake(map[string]time.Time{})
+	fakeRolesRepo := repository.NewUserRoleFake(map[string][]role.Role{})
+	rb := rbac.NewRBAC(fakeRolesRepo)
+	au := authorizer.NewAuthorizer(rb)
+
+	keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+	keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+	assert.Equal(t, nil, err)
+
+	return changelog.NewPersist(keyGen, timer.NewStub(now), &changeLogRepo, &userChangeLogRepo, au), &changeLogRepo
+}
+
+func TestExchanger_Exchange(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name     string
+		secretID string
+		revoked  bool
+		hasError bool
+	}{
+		{
+			name:     "valid RoleID and SecretID mint a scoped token",
+			secretID: "correct-secret",
+			revoked:  false,
+			hasError: false,
+		},
+		{
+			name:     "wrong SecretID is rejected",
+			secretID: "wrong-secret",
+			revoked:  false,
+			hasError: true,
+		},
+		{
+			name:     "revoked SecretID is rejected even when correct",
+			secretID: "correct-secret",
+			revoked:  true,
+			hasError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			credential := NewCredential("role-1", "correct-secret", []role.Role{role.Admin}, []string{"shortlink:update:*"}, now)
+			credential.Revoked = testCase.revoked
+			credentialRepo := repository.NewAPICredentialFake(map[string]entity.APICredential{
+				"role-1": credential,
+			})
+
+			tokenizer := crypto.NewTokenizerFake()
+			auth := authenticator.NewAuthenticator(tokenizer, timer.NewStub(now), time.Hour)
+			changeLog, changeLogRepo := newChangeLogForTest(t, now)
+			exchanger := NewExchanger(&credentialRepo, auth, changeLog, 5*time.Minute)
+
+			scopedToken, err := exchanger.Exchange("role-1", testCase.secretID)
+			if testCase.hasError {
+				assert.NotEqual(t, nil, err)
+				changes, err := changeLogRepo.GetChanges()
+				assert.Equal(t, nil, err)
+				assert.Equal(t, 0, len(changes))
+				return
+			}
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "role-1", scopedToken.RoleID())
+			assert.Equal(t, true, scopedToken.HasRole(role.Admin))
+
+			changes, err := changeLogRepo.GetChanges()
+			assert.Equal(t, nil, err)
+			assert.Equal(t, 1, len(changes))
+		})
+	}
+}
+
+func TestExchanger_RotateSecretID(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+
+	credential := NewCredential("role-1", "old-secret", nil, nil, now)
+	credentialRepo := repository.NewAPICredentialFake(map[string]entity.APICredential{
+		"role-1": credential,
+	})
+	tokenizer := crypto.NewTokenizerFake()
+	auth := authenticator.NewAuthenticator(tokenizer, timer.NewStub(now), time.Hour)
+	changeLog, changeLogRepo := newChangeLogForTest(t, now)
+	exchanger := NewExchanger(&credentialRepo, auth, changeLog, 5*time.Minute)
+
+	newSecretID, err := exchanger.RotateSecretID("role-1")
+	assert.Equal(t, nil, err)
+
+	_, err = exchanger.Exchange("role-1", "old-secret")
+	assert.NotEqual(t, nil, err)
+
+	_, err = exchanger.Exchange("role-1", newSecretID)
+	assert.Equal(t, nil, err)
+
+	changes, err := changeLogRepo.GetChanges()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(changes))
+}
+
+func TestExchanger_Revoke(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+
+	credential := NewCredential("role-1", "old-secret", nil, nil, now)
+	credentialRepo := repository.NewAPICredentialFake(map[string]entity.APICredential{
+		"role-1": credential,
+	})
+	tokenizer := crypto.NewTokenizerFake()
+	auth := authenticator.NewAuthenticator(tokenizer, timer.NewStub(now), time.Hour)
+	changeLog, changeLogRepo := newChangeLogForTest(t, now)
+	exchanger := NewExchanger(&credentialRepo, auth, changeLog, 5*time.Minute)
+
+	err := exchanger.Revoke("role-1")
+	assert.Equal(t, nil, err)
+
+	_, err = exchanger.Exchange("role-1", "old-secret")
+	assert.NotEqual(t, nil, err)
+
+	changes, err := changeLogRepo.GetChanges()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(changes))
+}
@@ -0,0 +1,194 @@
+package authenticator
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/short-d/app/fw/crypto"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+)
+
+// AuthToken is an opaque, signed credential proving a user's identity.
+type AuthToken struct {
+	user      entity.User
+	expireAt  time.Time
+	signature string
+}
+
+// User returns the user this token was issued to.
+func (a AuthToken) User() entity.User {
+	return a.user
+}
+
+type tokenPayload struct {
+	User     entity.User
+	ExpireAt time.Time
+}
+
+// Authenticator mints and verifies AuthTokens.
+type Authenticator struct {
+	tokenizer crypto.Tokenizer
+	timer     timer.Timer
+	duration  time.Duration
+}
+
+// GenerateToken mints a new AuthToken for user, valid for the
+// Authenticator's configured duration.
+func (a Authenticator) GenerateToken(user entity.User) (AuthToken, error) {
+	expireAt := a.timer.Now().Add(a.duration)
+	payload, err := json.Marshal(tokenPayload{User: user, ExpireAt: expireAt})
+	if err != nil {
+		return AuthToken{}, err
+	}
+	signature, err := a.tokenizer.Encode(string(payload))
+	if err != nil {
+		return AuthToken{}, err
+	}
+	return AuthToken{user: user, expireAt: expireAt, signature: signature}, nil
+}
+
+// IsValid returns whether token has not yet expired.
+func (a Authenticator) IsValid(token AuthToken) bool {
+	return a.timer.Now().Before(token.expireAt)
+}
+
+// Authenticate verifies that a signature previously produced by
+// GenerateToken is still valid and returns the user it was issued to.
+func (a Authenticator) Authenticate(signature string) (entity.User, error) {
+	payload, err := a.tokenizer.Decode(signature)
+	if err != nil {
+		return entity.User{}, err
+	}
+	var decoded tokenPayload
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		return entity.User{}, err
+	}
+	if a.timer.Now().After(decoded.ExpireAt) {
+		return entity.User{}, errors.New("auth token has expired")
+	}
+	return decoded.User, nil
+}
+
+// NewAuthenticator creates an Authenticator that signs tokens with
+// tokenizer and stamps them using tm, valid for duration.
+func NewAuthenticator(tokenizer crypto.Tokenizer, tm timer.Timer, duration time.Duration) Authenticator {
+	return Authenticator{tokenizer: tokenizer, timer: tm, duration: duration}
+}
+
+// ScopedToken is a short-lived, machine-to-machine credential minted from
+// an AppRole-style RoleID/SecretID exchange. It carries the RBAC roles and
+// per-alias ACLs (e.g. "shortlink:update:campaign-*") the exchange granted
+// it, rather than identifying a human user.
+type ScopedToken struct {
+	roleID    string
+	roles     []role.Role
+	aliasACLs []string
+	expireAt  time.Time
+	signature string
+}
+
+// RoleID returns the AppRole RoleID this token was exchanged for.
+func (s ScopedToken) RoleID() string {
+	return s.roleID
+}
+
+// HasRole reports whether want was granted to this token.
+func (s ScopedToken) HasRole(want role.Role) bool {
+	for _, got := range s.roles {
+		if got == want {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAliasScope reports whether this token is allowed to perform action on
+// alias, by matching alias against the token's "<resource>:<action>:<glob>"
+// ACLs.
+func (s ScopedToken) HasAliasScope(resource string, action string, alias string) bool {
+	for _, acl := range s.aliasACLs {
+		resourceAction, glob, ok := splitAliasACL(acl)
+		if !ok || resourceAction != resource+":"+action {
+			continue
+		}
+		if matched, _ := path.Match(glob, alias); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func splitAliasACL(acl string) (resourceAction string, glob string, ok bool) {
+	lastColon := -1
+	for i := len(acl) - 1; i >= 0; i-- {
+		if acl[i] == ':' {
+			lastColon = i
+			break
+		}
+	}
+	if lastColon < 0 {
+		return "", "", false
+	}
+	return acl[:lastColon], acl[lastColon+1:], true
+}
+
+type scopedTokenPayload struct {
+	RoleID    string
+	Roles     []role.Role
+	AliasACLs []string
+	ExpireAt  time.Time
+}
+
+// GenerateScopedToken mints a new ScopedToken for roleID, carrying roles
+// and aliasACLs, valid for ttl.
+func (a Authenticator) GenerateScopedToken(roleID string, roles []role.Role, aliasACLs []string, ttl time.Duration) (ScopedToken, error) {
+	expireAt := a.timer.Now().Add(ttl)
+	payload, err := json.Marshal(scopedTokenPayload{
+		RoleID:    roleID,
+		Roles:     roles,
+		AliasACLs: aliasACLs,
+		ExpireAt:  expireAt,
+	})
+	if err != nil {
+		return ScopedToken{}, err
+	}
+	signature, err := a.tokenizer.Encode(string(payload))
+	if err != nil {
+		return ScopedToken{}, err
+	}
+	return ScopedToken{
+		roleID:    roleID,
+		roles:     roles,
+		aliasACLs: aliasACLs,
+		expireAt:  expireAt,
+		signature: signature,
+	}, nil
+}
+
+// AuthenticateScopedToken verifies that a signature previously produced by
+// GenerateScopedToken is still valid and returns the ScopedToken it was
+// issued as.
+func (a Authenticator) AuthenticateScopedToken(signature string) (ScopedToken, error) {
+	payload, err := a.tokenizer.Decode(signature)
+	if err != nil {
+		return ScopedToken{}, err
+	}
+	var decoded scopedTokenPayload
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		return ScopedToken{}, err
+	}
+	if a.timer.Now().After(decoded.ExpireAt) {
+		return ScopedToken{}, errors.New("scoped token has expired")
+	}
+	return ScopedToken{
+		roleID:    decoded.RoleID,
+		roles:     decoded.Roles,
+		aliasACLs: decoded.AliasACLs,
+		expireAt:  decoded.ExpireAt,
+		signature: signature,
+	}, nil
+}
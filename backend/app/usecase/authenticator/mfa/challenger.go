@@ -0,0 +1,18 @@
+// Package mfa gates destructive actions behind a second factor. Challenger
+// is kept minimal so other factors (WebAuthn, SMS) can implement it
+// alongside TOTPChallenger without touching call sites.
+package mfa
+
+import "github.com/short-d/short/backend/app/entity"
+
+// Challenger issues and verifies MFA challenges.
+type Challenger interface {
+	// Challenge starts a new challenge for user and returns an opaque ID
+	// the caller must present, alongside the user's response, to Verify.
+	Challenge(user entity.User) (challengeID string, err error)
+
+	// Verify reports whether response satisfies the challenge identified
+	// by challengeID. A successful verification consumes the challenge;
+	// it cannot be verified again.
+	Verify(challengeID string, response string) (bool, error)
+}
@@ -0,0 +1,36 @@
+package mfa
+
+import (
+	"errors"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// ChallengerFake is a deterministic Challenger for tests: it always issues
+// challengeID and only accepts validResponse for it.
+type ChallengerFake struct {
+	challengeID   string
+	validResponse string
+}
+
+var _ Challenger = ChallengerFake{}
+
+// Challenge always returns the fake's fixed challengeID.
+func (c ChallengerFake) Challenge(user entity.User) (string, error) {
+	return c.challengeID, nil
+}
+
+// Verify reports whether challengeID and response match what the fake was
+// seeded with.
+func (c ChallengerFake) Verify(challengeID string, response string) (bool, error) {
+	if challengeID != c.challengeID {
+		return false, errors.New("MFA challenge not found")
+	}
+	return response == c.validResponse, nil
+}
+
+// NewChallengerFake creates a ChallengerFake that issues challengeID and
+// accepts only validResponse for it.
+func NewChallengerFake(challengeID string, validResponse string) ChallengerFake {
+	return ChallengerFake{challengeID: challengeID, validResponse: validResponse}
+}
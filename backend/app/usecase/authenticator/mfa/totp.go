@@ -0,0 +1,147 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+)
+
+const totpStep = 30 * time.Second
+const totpDigits = 6
+
+type pendingChallenge struct {
+	secretKey string
+	expireAt  time.Time
+}
+
+// TOTPChallenger is a Challenger backed by time-based one-time passwords
+// (RFC 6238), generated from entity.User.MFASecretKey.
+type TOTPChallenger struct {
+	mutex        sync.Mutex
+	pending      map[string]pendingChallenge
+	timer        timer.Timer
+	challengeTTL time.Duration
+}
+
+var _ Challenger = (*TOTPChallenger)(nil)
+
+// Challenge starts a TOTP challenge for user, valid for the challenger's
+// configured TTL.
+func (c *TOTPChallenger) Challenge(user entity.User) (string, error) {
+	if user.MFASecretKey == "" {
+		return "", errors.New("user has not enrolled in MFA")
+	}
+
+	challengeID, err := randomChallengeID()
+	if err != nil {
+		return "", err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.pending == nil {
+		c.pending = make(map[string]pendingChallenge)
+	}
+	c.pending[challengeID] = pendingChallenge{
+		secretKey: user.MFASecretKey,
+		expireAt:  c.timer.Now().Add(c.challengeTTL),
+	}
+	return challengeID, nil
+}
+
+// Verify checks response against the current and previous TOTP code for
+// the challenge's secret, tolerating normal clock drift between client
+// and server.
+func (c *TOTPChallenger) Verify(challengeID string, response string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	pending, ok := c.pending[challengeID]
+	if !ok {
+		return false, errors.New("MFA challenge not found")
+	}
+	now := c.timer.Now()
+	if now.After(pending.expireAt) {
+		delete(c.pending, challengeID)
+		return false, errors.New("MFA challenge has expired")
+	}
+
+	matched := matchesTOTP(pending.secretKey, response, now)
+	if matched {
+		delete(c.pending, challengeID)
+	}
+	return matched, nil
+}
+
+// NewTOTPChallenger creates a TOTPChallenger whose challenges expire after
+// challengeTTL.
+func NewTOTPChallenger(tm timer.Timer, challengeTTL time.Duration) *TOTPChallenger {
+	return &TOTPChallenger{
+		pending:      make(map[string]pendingChallenge),
+		timer:        tm,
+		challengeTTL: challengeTTL,
+	}
+}
+
+func matchesTOTP(secretKey string, response string, now time.Time) bool {
+	for _, step := range []time.Time{now, now.Add(-totpStep)} {
+		expected, err := generateTOTP(secretKey, step)
+		if err != nil {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(response)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateTOTP computes the RFC 6238 time-based one-time password for
+// secretKey (a base32-encoded shared secret) at the 30-second step
+// containing at.
+func generateTOTP(secretKey string, at time.Time) (string, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	counter := uint64(at.Unix() / int64(totpStep.Seconds()))
+	counterBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(counterBytes, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counterBytes)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	code := truncated % pow10(totpDigits)
+	return fmt.Sprintf("%0*d", totpDigits, code), nil
+}
+
+func pow10(n int) uint32 {
+	result := uint32(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+func randomChallengeID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
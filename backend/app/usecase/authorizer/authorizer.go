@@ -0,0 +1,30 @@
+package authorizer
+
+import (
+	"errors"
+
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+)
+
+// Authorizer decides whether a user is allowed to perform an action.
+type Authorizer struct {
+	rbac rbac.RBAC
+}
+
+// IsAuthorized returns an error unless userID has been granted want.
+func (a Authorizer) IsAuthorized(userID string, want role.Role) error {
+	ok, err := a.rbac.HasRole(userID, want)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("user is not authorized to perform this action")
+	}
+	return nil
+}
+
+// NewAuthorizer creates an Authorizer backed by rb.
+func NewAuthorizer(rb rbac.RBAC) Authorizer {
+	return Authorizer{rbac: rb}
+}
@@ -0,0 +1,30 @@
+package rbac
+
+import (
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// RBAC decides which roles a user has been granted.
+type RBAC struct {
+	userRoleRepo repository.UserRoleRepo
+}
+
+// HasRole reports whether userID has been granted want.
+func (r RBAC) HasRole(userID string, want role.Role) (bool, error) {
+	roles, err := r.userRoleRepo.GetRoles(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, got := range roles {
+		if got == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewRBAC creates an RBAC backed by userRoleRepo.
+func NewRBAC(userRoleRepo repository.UserRoleRepo) RBAC {
+	return RBAC{userRoleRepo: userRoleRepo}
+}
@@ -0,0 +1,9 @@
+package role
+
+// Role identifies a set of permissions a user can be granted.
+type Role string
+
+const (
+	// Admin can perform operational, account-wide short link management.
+	Admin Role = "admin"
+)
@@ -0,0 +1,61 @@
+package changelog
+
+import (
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authorizer"
+	"github.com/short-d/short/backend/app/usecase/keygen"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// Persist records product changes so affected users can be notified
+// through the changelog.
+type Persist struct {
+	keyGen            keygen.KeyGenerator
+	timer             timer.Timer
+	changeLogRepo     repository.ChangeLogRepo
+	userChangeLogRepo repository.UserChangeLogRepo
+	authorizer        authorizer.Authorizer
+}
+
+// Log records summary as a change made on behalf of userID, returning the
+// ID it was recorded under.
+func (p Persist) Log(userID string, summary string) (string, error) {
+	key, err := p.keyGen.NewKey()
+	if err != nil {
+		return "", err
+	}
+	change := entity.Change{
+		ID:        string(key),
+		Summary:   summary,
+		UserID:    userID,
+		CreatedAt: p.timer.Now(),
+	}
+	if err := p.changeLogRepo.CreateChange(change); err != nil {
+		return "", err
+	}
+	return change.ID, nil
+}
+
+// Delete removes the change recorded under id, used to undo a log entry
+// written for a mutation that was later rolled back.
+func (p Persist) Delete(id string) error {
+	return p.changeLogRepo.DeleteChange(id)
+}
+
+// NewPersist creates a Persist backed by the given collaborators.
+func NewPersist(
+	keyGen keygen.KeyGenerator,
+	tm timer.Timer,
+	changeLogRepo repository.ChangeLogRepo,
+	userChangeLogRepo repository.UserChangeLogRepo,
+	au authorizer.Authorizer,
+) Persist {
+	return Persist{
+		keyGen:            keyGen,
+		timer:             tm,
+		changeLogRepo:     changeLogRepo,
+		userChangeLogRepo: userChangeLogRepo,
+		authorizer:        au,
+	}
+}
@@ -0,0 +1,83 @@
+package keygen
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// Key is a short, unique identifier produced by a KeyGenerator.
+type Key string
+
+// KeyFetcher supplies batches of pre-generated keys for a KeyGenerator to
+// hand out, so key generation never blocks on the hot path.
+type KeyFetcher interface {
+	FetchKeys(count int) ([]Key, error)
+}
+
+// KeyFetcherFake is an in-memory KeyFetcher for tests.
+type KeyFetcherFake struct {
+	keys []Key
+}
+
+var _ KeyFetcher = (*KeyFetcherFake)(nil)
+
+// FetchKeys returns up to count keys from the fake's preloaded pool.
+func (k *KeyFetcherFake) FetchKeys(count int) ([]Key, error) {
+	if count > len(k.keys) {
+		count = len(k.keys)
+	}
+	keys := k.keys[:count]
+	k.keys = k.keys[count:]
+	return keys, nil
+}
+
+// NewKeyFetcherFake creates a KeyFetcherFake seeded with keys.
+func NewKeyFetcherFake(keys []Key) KeyFetcherFake {
+	return KeyFetcherFake{keys: keys}
+}
+
+// KeyGenerator hands out the fetcher's pre-generated keys, falling back to
+// a random suffix of a fixed length once the fetcher's pool runs dry.
+// Random keys are not checked against keys handed out before.
+type KeyGenerator struct {
+	keyLength  int
+	keyFetcher KeyFetcher
+}
+
+// NewKey returns the next pre-generated key, or a random key of the
+// generator's length once the pool is empty.
+func (g *KeyGenerator) NewKey() (Key, error) {
+	keys, err := g.keyFetcher.FetchKeys(1)
+	if err != nil {
+		return "", err
+	}
+	if len(keys) > 0 {
+		return keys[0], nil
+	}
+	suffix, err := randomSuffix(g.keyLength)
+	if err != nil {
+		return "", err
+	}
+	return Key(suffix), nil
+}
+
+func randomSuffix(length int) (string, error) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i := range buf {
+		buf[i] = alphabet[int(buf[i])%len(alphabet)]
+	}
+	return string(buf), nil
+}
+
+// NewKeyGenerator creates a KeyGenerator producing keys of keyLength,
+// sourced from keyFetcher.
+func NewKeyGenerator(keyLength int, keyFetcher KeyFetcher) (KeyGenerator, error) {
+	if keyLength <= 0 {
+		return KeyGenerator{}, errors.New("keyLength must be positive")
+	}
+	return KeyGenerator{keyLength: keyLength, keyFetcher: keyFetcher}, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// APICredentialRepo accesses machine-to-machine API credentials.
+type APICredentialRepo interface {
+	GetCredential(roleID string) (entity.APICredential, error)
+	CreateCredential(credential entity.APICredential) error
+	UpdateCredential(credential entity.APICredential) error
+}
+
+// APICredentialFake is an in-memory APICredentialRepo for tests.
+type APICredentialFake struct {
+	mutex       sync.Mutex
+	credentials map[string]entity.APICredential
+}
+
+var _ APICredentialRepo = (*APICredentialFake)(nil)
+
+// GetCredential fetches the credential for roleID.
+func (a *APICredentialFake) GetCredential(roleID string) (entity.APICredential, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	credential, ok := a.credentials[roleID]
+	if !ok {
+		return entity.APICredential{}, errors.New("API credential not found")
+	}
+	return credential, nil
+}
+
+// CreateCredential inserts a new credential.
+func (a *APICredentialFake) CreateCredential(credential entity.APICredential) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if _, ok := a.credentials[credential.RoleID]; ok {
+		return errors.New("RoleID already exists")
+	}
+	a.credentials[credential.RoleID] = credential
+	return nil
+}
+
+// UpdateCredential replaces an existing credential, used for secret
+// rotation and revocation.
+func (a *APICredentialFake) UpdateCredential(credential entity.APICredential) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if _, ok := a.credentials[credential.RoleID]; !ok {
+		return errors.New("API credential not found")
+	}
+	a.credentials[credential.RoleID] = credential
+	return nil
+}
+
+// NewAPICredentialFake creates an APICredentialFake seeded with credentials.
+func NewAPICredentialFake(credentials map[string]entity.APICredential) APICredentialFake {
+	cloned := make(map[string]entity.APICredential, len(credentials))
+	for roleID, credential := range credentials {
+		cloned[roleID] = credential
+	}
+	return APICredentialFake{credentials: cloned}
+}
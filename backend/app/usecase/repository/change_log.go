@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// ChangeLogRepo persists the history of changes made to entities.
+type ChangeLogRepo interface {
+	CreateChange(change entity.Change) error
+	GetChanges() ([]entity.Change, error)
+	DeleteChange(id string) error
+}
+
+// ChangeLogFake is an in-memory ChangeLogRepo for tests.
+type ChangeLogFake struct {
+	mutex   sync.Mutex
+	changes []entity.Change
+}
+
+var _ ChangeLogRepo = (*ChangeLogFake)(nil)
+
+// CreateChange appends change to the log.
+func (c *ChangeLogFake) CreateChange(change entity.Change) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+// GetChanges returns every change recorded so far.
+func (c *ChangeLogFake) GetChanges() ([]entity.Change, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.changes, nil
+}
+
+// DeleteChange removes the change recorded under id, used to undo a log
+// entry written for a mutation that was later rolled back.
+func (c *ChangeLogFake) DeleteChange(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, change := range c.changes {
+		if change.ID == id {
+			c.changes = append(c.changes[:i], c.changes[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("change not found")
+}
+
+// NewChangeLogFake creates a ChangeLogFake seeded with changes.
+func NewChangeLogFake(changes []entity.Change) ChangeLogFake {
+	return ChangeLogFake{changes: changes}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// FeedStateRepo persists the sync progress of a risk.Feed.
+type FeedStateRepo interface {
+	GetFeedState(feedName string) (entity.FeedState, error)
+	SaveFeedState(state entity.FeedState) error
+}
+
+// FeedStateFake is an in-memory FeedStateRepo for tests.
+type FeedStateFake struct {
+	mutex  sync.Mutex
+	states map[string]entity.FeedState
+}
+
+var _ FeedStateRepo = (*FeedStateFake)(nil)
+
+// GetFeedState returns the last persisted state for feedName, or a zero
+// value if the feed has never synced before.
+func (f *FeedStateFake) GetFeedState(feedName string) (entity.FeedState, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.states[feedName], nil
+}
+
+// SaveFeedState persists state, keyed by its FeedName.
+func (f *FeedStateFake) SaveFeedState(state entity.FeedState) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.states[state.FeedName] = state
+	return nil
+}
+
+// NewFeedStateFake creates a FeedStateFake seeded with states.
+func NewFeedStateFake(states map[string]entity.FeedState) FeedStateFake {
+	cloned := make(map[string]entity.FeedState, len(states))
+	for name, state := range states {
+		cloned[name] = state
+	}
+	return FeedStateFake{states: cloned}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// ShortLinkRepo accesses canonical short link rows, keyed by alias.
+type ShortLinkRepo interface {
+	IsAliasExist(alias string) (bool, error)
+	GetShortLinkByAlias(alias string) (entity.ShortLink, error)
+	GetShortLinkByID(id string) (entity.ShortLink, error)
+	CreateShortLink(shortLink entity.ShortLink) error
+	UpdateShortLink(shortLink entity.ShortLink) error
+	DeleteShortLink(alias string) error
+}
+
+// ShortLinkFake is an in-memory ShortLinkRepo for tests.
+type ShortLinkFake struct {
+	mutex      sync.Mutex
+	shortLinks map[string]entity.ShortLink
+}
+
+var _ ShortLinkRepo = (*ShortLinkFake)(nil)
+
+// IsAliasExist returns whether a canonical row already uses alias.
+func (s *ShortLinkFake) IsAliasExist(alias string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.shortLinks[alias]
+	return ok, nil
+}
+
+// GetShortLinkByAlias fetches the canonical row for alias.
+func (s *ShortLinkFake) GetShortLinkByAlias(alias string) (entity.ShortLink, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	shortLink, ok := s.shortLinks[alias]
+	if !ok {
+		return entity.ShortLink{}, errors.New("short link not found")
+	}
+	return shortLink, nil
+}
+
+// GetShortLinkByID fetches the canonical row whose ID is id, used to
+// resolve a secondary alias once its canonical ID is known.
+func (s *ShortLinkFake) GetShortLinkByID(id string) (entity.ShortLink, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, shortLink := range s.shortLinks {
+		if shortLink.ID == id {
+			return shortLink, nil
+		}
+	}
+	return entity.ShortLink{}, errors.New("short link not found")
+}
+
+// CreateShortLink inserts a new canonical row.
+func (s *ShortLinkFake) CreateShortLink(shortLink entity.ShortLink) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.shortLinks[shortLink.Alias]; ok {
+		return errors.New("alias already exists")
+	}
+	s.shortLinks[shortLink.Alias] = shortLink
+	return nil
+}
+
+// UpdateShortLink replaces a canonical row, possibly under a new alias.
+func (s *ShortLinkFake) UpdateShortLink(shortLink entity.ShortLink) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for alias, existing := range s.shortLinks {
+		if existing.ID == shortLink.ID {
+			delete(s.shortLinks, alias)
+			break
+		}
+	}
+	s.shortLinks[shortLink.Alias] = shortLink
+	return nil
+}
+
+// DeleteShortLink removes the canonical row for alias.
+func (s *ShortLinkFake) DeleteShortLink(alias string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.shortLinks[alias]; !ok {
+		return errors.New("short link not found")
+	}
+	delete(s.shortLinks, alias)
+	return nil
+}
+
+// NewShortLinkFake creates a ShortLinkFake seeded with shortLinks.
+func NewShortLinkFake(shortLinks map[string]entity.ShortLink) ShortLinkFake {
+	cloned := make(map[string]entity.ShortLink, len(shortLinks))
+	for alias, shortLink := range shortLinks {
+		cloned[alias] = shortLink
+	}
+	return ShortLinkFake{shortLinks: cloned}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// ShortLinkAliasRepo accesses alias→canonical-ID rows. It lets several
+// aliases, including vanity and legacy ones, resolve to a single canonical
+// short link.
+type ShortLinkAliasRepo interface {
+	IsAliasExist(alias string) (bool, error)
+	GetCanonicalID(alias string) (string, error)
+	GetAlias(alias string) (entity.ShortLinkAlias, error)
+	CreateAlias(alias entity.ShortLinkAlias) error
+	DeleteAlias(alias string) error
+	DeleteAliasesByCanonicalID(canonicalID string) error
+}
+
+// ShortLinkAliasFake is an in-memory ShortLinkAliasRepo for tests.
+type ShortLinkAliasFake struct {
+	mutex   sync.Mutex
+	aliases map[string]entity.ShortLinkAlias
+}
+
+var _ ShortLinkAliasRepo = (*ShortLinkAliasFake)(nil)
+
+// IsAliasExist returns whether alias is already registered as a secondary
+// alias. Canonical aliases are kept in ShortLinkRepo and are not checked
+// here.
+func (s *ShortLinkAliasFake) IsAliasExist(alias string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.aliases[alias]
+	return ok, nil
+}
+
+// GetCanonicalID resolves alias to the canonical short link ID it points at.
+func (s *ShortLinkAliasFake) GetCanonicalID(alias string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	row, ok := s.aliases[alias]
+	if !ok {
+		return "", errors.New("alias not found")
+	}
+	return row.CanonicalID, nil
+}
+
+// GetAlias fetches the full row registered under alias, used to restore it
+// verbatim if a change that removed it is later rolled back.
+func (s *ShortLinkAliasFake) GetAlias(alias string) (entity.ShortLinkAlias, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	row, ok := s.aliases[alias]
+	if !ok {
+		return entity.ShortLinkAlias{}, errors.New("alias not found")
+	}
+	return row, nil
+}
+
+// CreateAlias registers a new secondary alias.
+func (s *ShortLinkAliasFake) CreateAlias(alias entity.ShortLinkAlias) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.aliases[alias.Alias]; ok {
+		return errors.New("alias already exists")
+	}
+	s.aliases[alias.Alias] = alias
+	return nil
+}
+
+// DeleteAlias removes a single secondary alias.
+func (s *ShortLinkAliasFake) DeleteAlias(alias string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.aliases[alias]; !ok {
+		return errors.New("alias not found")
+	}
+	delete(s.aliases, alias)
+	return nil
+}
+
+// DeleteAliasesByCanonicalID cascade-deletes every alias pointing at
+// canonicalID, called when the canonical row itself is deleted.
+func (s *ShortLinkAliasFake) DeleteAliasesByCanonicalID(canonicalID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for alias, row := range s.aliases {
+		if row.CanonicalID == canonicalID {
+			delete(s.aliases, alias)
+		}
+	}
+	return nil
+}
+
+// NewShortLinkAliasFake creates a ShortLinkAliasFake seeded with aliases.
+func NewShortLinkAliasFake(aliases map[string]entity.ShortLinkAlias) ShortLinkAliasFake {
+	cloned := make(map[string]entity.ShortLinkAlias, len(aliases))
+	for alias, row := range aliases {
+		cloned[alias] = row
+	}
+	return ShortLinkAliasFake{aliases: cloned}
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// UserChangeLogRepo tracks the last time each user's changelog was viewed.
+type UserChangeLogRepo interface {
+	GetLastViewedAt(user entity.User) (time.Time, error)
+	UpdateLastViewedAt(user entity.User, viewedAt time.Time) error
+}
+
+// UserChangeLogFake is an in-memory UserChangeLogRepo for tests.
+type UserChangeLogFake struct {
+	mutex      sync.Mutex
+	lastViewed map[string]time.Time
+}
+
+var _ UserChangeLogRepo = (*UserChangeLogFake)(nil)
+
+// GetLastViewedAt returns the last time user viewed the changelog.
+func (u *UserChangeLogFake) GetLastViewedAt(user entity.User) (time.Time, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.lastViewed[user.ID], nil
+}
+
+// UpdateLastViewedAt records that user viewed the changelog at viewedAt.
+func (u *UserChangeLogFake) UpdateLastViewedAt(user entity.User, viewedAt time.Time) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.lastViewed[user.ID] = viewedAt
+	return nil
+}
+
+// NewUserChangeLogFake creates a UserChangeLogFake seeded with lastViewed.
+func NewUserChangeLogFake(lastViewed map[string]time.Time) UserChangeLogFake {
+	return UserChangeLogFake{lastViewed: lastViewed}
+}
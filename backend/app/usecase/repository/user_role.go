@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+)
+
+// UserRoleRepo looks up the roles granted to a user.
+type UserRoleRepo interface {
+	GetRoles(userID string) ([]role.Role, error)
+}
+
+// UserRoleFake is an in-memory UserRoleRepo for tests.
+type UserRoleFake struct {
+	roles map[string][]role.Role
+}
+
+var _ UserRoleRepo = UserRoleFake{}
+
+// GetRoles returns the roles granted to userID.
+func (u UserRoleFake) GetRoles(userID string) ([]role.Role, error) {
+	return u.roles[userID], nil
+}
+
+// NewUserRoleFake creates a UserRoleFake seeded with roles.
+func NewUserRoleFake(roles map[string][]role.Role) UserRoleFake {
+	return UserRoleFake{roles: roles}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// UserShortLinkRepo tracks which user owns which short links.
+type UserShortLinkRepo interface {
+	IsUserShortLinkExist(userID string, alias string) (bool, error)
+	CreateUserShortLink(user entity.User, shortLink entity.ShortLink) error
+	GetOwner(alias string) (entity.User, error)
+	TransferOwnership(alias string, newOwner entity.User) error
+}
+
+// UserShortLinkRepoFake is an in-memory UserShortLinkRepo for tests.
+type UserShortLinkRepoFake struct {
+	mutex      sync.Mutex
+	users      []entity.User
+	shortLinks []entity.ShortLink
+	owners     map[string]string
+}
+
+var _ UserShortLinkRepo = (*UserShortLinkRepoFake)(nil)
+
+// IsUserShortLinkExist returns whether alias is owned by userID.
+func (u *UserShortLinkRepoFake) IsUserShortLinkExist(userID string, alias string) (bool, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	owner, ok := u.owners[alias]
+	return ok && owner == userID, nil
+}
+
+// CreateUserShortLink records that user owns shortLink.
+func (u *UserShortLinkRepoFake) CreateUserShortLink(user entity.User, shortLink entity.ShortLink) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.owners[shortLink.Alias] = user.ID
+	return nil
+}
+
+// GetOwner returns the user who owns alias.
+func (u *UserShortLinkRepoFake) GetOwner(alias string) (entity.User, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	userID, ok := u.owners[alias]
+	if !ok {
+		return entity.User{}, errors.New("owner not found")
+	}
+	for _, user := range u.users {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return entity.User{}, errors.New("owner not found")
+}
+
+// TransferOwnership re-points alias at newOwner, used when moving a short
+// link between accounts.
+func (u *UserShortLinkRepoFake) TransferOwnership(alias string, newOwner entity.User) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.owners[alias]; !ok {
+		return errors.New("owner not found")
+	}
+	u.owners[alias] = newOwner.ID
+	return nil
+}
+
+// NewUserShortLinkRepoFake creates a UserShortLinkRepoFake seeded with the
+// given users, recording every short link in shortLinks as owned by the
+// first user in users. With no users, no short link has an owner.
+func NewUserShortLinkRepoFake(users []entity.User, shortLinks []entity.ShortLink) UserShortLinkRepoFake {
+	owners := make(map[string]string, len(shortLinks))
+	for _, shortLink := range shortLinks {
+		if len(users) == 0 {
+			continue
+		}
+		owners[shortLink.Alias] = users[0].ID
+	}
+	return UserShortLinkRepoFake{
+		users:      users,
+		shortLinks: shortLinks,
+		owners:     owners,
+	}
+}
@@ -0,0 +1,24 @@
+package risk
+
+// BlackList looks up whether a URL is known to be malicious.
+type BlackList interface {
+	IsBlacklisted(url string) (bool, error)
+}
+
+// BlackListFake is an in-memory BlackList for tests.
+type BlackListFake struct {
+	blacklisted map[string]bool
+}
+
+var _ BlackList = BlackListFake{}
+
+// IsBlacklisted returns whether url has been flagged as malicious.
+func (b BlackListFake) IsBlacklisted(url string) (bool, error) {
+	_, ok := b.blacklisted[url]
+	return ok, nil
+}
+
+// NewBlackListFake creates a BlackListFake seeded with blacklisted.
+func NewBlackListFake(blacklisted map[string]bool) BlackListFake {
+	return BlackListFake{blacklisted: blacklisted}
+}
@@ -0,0 +1,94 @@
+package risk
+
+import (
+	"crypto/sha256"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// canonicalizeURL normalizes url the way a threat-intel feed canonicalizes
+// entries before hashing them: the host is lowercased, default ports are
+// stripped, "." and ".." path segments are resolved, and percent-encoded
+// unreserved characters are decoded.
+func canonicalizeURL(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", err
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !isDefaultPort(u.Scheme, port) {
+		host = host + ":" + port
+	}
+
+	cleanPath := path.Clean("/" + u.EscapedPath())
+	decodedPath, err := decodeUnreserved(cleanPath)
+	if err != nil {
+		return "", err
+	}
+
+	return host + decodedPath, nil
+}
+
+func isDefaultPort(scheme string, port string) bool {
+	switch scheme {
+	case "http":
+		return port == "80"
+	case "https":
+		return port == "443"
+	default:
+		return false
+	}
+}
+
+func decodeUnreserved(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			decoded, err := strconv.ParseInt(s[i+1:i+3], 16, 16)
+			if err == nil && isUnreserved(byte(decoded)) {
+				sb.WriteByte(byte(decoded))
+				i += 2
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String(), nil
+}
+
+func isUnreserved(b byte) bool {
+	switch {
+	case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+		return true
+	case b == '-' || b == '_' || b == '.' || b == '~':
+		return true
+	default:
+		return false
+	}
+}
+
+// hashVariants computes SHA-256 over the URL-suffix/prefix variants a
+// threat-intel feed typically indexes: host+path, host-only, and the host
+// with the path stripped down to "/".
+func hashVariants(canonical string) [][32]byte {
+	host := canonical
+	pathStart := strings.IndexByte(canonical, '/')
+	if pathStart >= 0 {
+		host = canonical[:pathStart]
+	}
+
+	variants := []string{canonical, host, host + "/"}
+	hashes := make([][32]byte, 0, len(variants))
+	seen := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		if seen[variant] {
+			continue
+		}
+		seen[variant] = true
+		hashes = append(hashes, sha256.Sum256([]byte(variant)))
+	}
+	return hashes
+}
@@ -0,0 +1,57 @@
+package risk
+
+// Detector flags whether a URL is risky to redirect users to.
+type Detector struct {
+	blacklist BlackList
+	prefixSet *PrefixSet
+	feed      Feed
+}
+
+// IsMalicious returns whether url is known to be malicious.
+func (d Detector) IsMalicious(url string) (bool, error) {
+	return d.blacklist.IsBlacklisted(url)
+}
+
+// IsURLMalicious canonicalizes url, hashes its suffix/prefix variants, and
+// checks each one's 4-byte prefix against the syncer-populated PrefixSet.
+// A prefix hit is not flagged until Feed.ConfirmFullHash confirms it isn't
+// a collision, so IsURLMalicious never blocks on a bare prefix match.
+func (d Detector) IsURLMalicious(url string) (bool, error) {
+	if d.prefixSet == nil || d.feed == nil {
+		return false, nil
+	}
+
+	canonical, err := canonicalizeURL(url)
+	if err != nil {
+		return false, err
+	}
+
+	for _, fullHash := range hashVariants(canonical) {
+		var prefix [4]byte
+		copy(prefix[:], fullHash[:4])
+
+		if !d.prefixSet.Has(prefix) {
+			continue
+		}
+
+		confirmed, err := d.feed.ConfirmFullHash(prefix, fullHash)
+		if err != nil {
+			return false, err
+		}
+		if confirmed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewDetector creates a Detector backed by blacklist.
+func NewDetector(blacklist BlackList) Detector {
+	return Detector{blacklist: blacklist}
+}
+
+// NewDetectorWithFeedSync creates a Detector whose IsURLMalicious checks
+// are additionally backed by prefixSet and feed.
+func NewDetectorWithFeedSync(blacklist BlackList, prefixSet *PrefixSet, feed Feed) Detector {
+	return Detector{blacklist: blacklist, prefixSet: prefixSet, feed: feed}
+}
@@ -0,0 +1,87 @@
+// +build !integration all
+
+package risk
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/short-d/app/fw/assert"
+)
+
+func TestDetector_IsURLMalicious(t *testing.T) {
+	t.Parallel()
+
+	maliciousHash := sha256.Sum256([]byte("malware.wicar.org/data/ms14_064_ole_not_xp.html"))
+	var maliciousPrefix [4]byte
+	copy(maliciousPrefix[:], maliciousHash[:4])
+
+	testCases := []struct {
+		name          string
+		url           string
+		prefixSeeded  [][4]byte
+		confirmedHash map[[32]byte]bool
+		expected      bool
+	}{
+		{
+			name:         "canonicalizes scheme, default port, and case before matching",
+			url:          "HTTP://Malware.Wicar.org:80/data/ms14_064_ole_not_xp.html",
+			prefixSeeded: [][4]byte{maliciousPrefix},
+			confirmedHash: map[[32]byte]bool{
+				maliciousHash: true,
+			},
+			expected: true,
+		},
+		{
+			name:         "resolves dot path segments before matching",
+			url:          "http://malware.wicar.org/a/../data/./ms14_064_ole_not_xp.html",
+			prefixSeeded: [][4]byte{maliciousPrefix},
+			confirmedHash: map[[32]byte]bool{
+				maliciousHash: true,
+			},
+			expected: true,
+		},
+		{
+			name:         "decodes percent-encoded unreserved characters before matching",
+			url:          "http://malware.wicar.org/data/ms14_064_ole_not_xp%2Ehtml",
+			prefixSeeded: [][4]byte{maliciousPrefix},
+			confirmedHash: map[[32]byte]bool{
+				maliciousHash: true,
+			},
+			expected: true,
+		},
+		{
+			name:          "a bare prefix collision with no full-hash confirmation must not block",
+			url:           "http://malware.wicar.org/data/ms14_064_ole_not_xp.html",
+			prefixSeeded:  [][4]byte{maliciousPrefix},
+			confirmedHash: map[[32]byte]bool{},
+			expected:      false,
+		},
+		{
+			name:         "no prefix hit at all",
+			url:          "https://www.google.com/",
+			prefixSeeded: [][4]byte{maliciousPrefix},
+			confirmedHash: map[[32]byte]bool{
+				maliciousHash: true,
+			},
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			prefixSet := NewPrefixSet()
+			for _, prefix := range testCase.prefixSeeded {
+				prefixSet.Add(prefix)
+			}
+			feed := NewFeedFake(nil, "", testCase.confirmedHash, nil)
+			blacklist := NewBlackListFake(map[string]bool{})
+
+			detector := NewDetectorWithFeedSync(blacklist, prefixSet, feed)
+			isMalicious, err := detector.IsURLMalicious(testCase.url)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, testCase.expected, isMalicious)
+		})
+	}
+}
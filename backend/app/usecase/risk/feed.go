@@ -0,0 +1,26 @@
+package risk
+
+import (
+	"context"
+	"time"
+)
+
+// FeedEntry is a single reputation record pulled from a threat-intel Feed.
+// Only the hash prefix is kept in memory; FullHash is used solely to seed
+// a Feed's own ConfirmFullHash lookup and is never persisted.
+type FeedEntry struct {
+	HashPrefix [4]byte
+	FullHash   [32]byte
+}
+
+// Feed is a pluggable source of URL/host reputation data.
+type Feed interface {
+	// Fetch returns the entries published after since (a feed that cannot
+	// filter by time may return all of its entries), plus an opaque cursor
+	// describing how far this fetch got, for the caller to persist.
+	Fetch(ctx context.Context, since time.Time) (entries []FeedEntry, cursor string, err error)
+
+	// ConfirmFullHash is called on a hash-prefix hit to verify the match
+	// isn't a prefix collision before a URL is flagged as malicious.
+	ConfirmFullHash(prefix [4]byte, fullHash [32]byte) (bool, error)
+}
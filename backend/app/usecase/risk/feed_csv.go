@@ -0,0 +1,81 @@
+package risk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// CSVFeed reads reputation rows of the form `full_hash,published_at_unix`
+// from a CSV file on disk.
+type CSVFeed struct {
+	path string
+}
+
+var _ Feed = CSVFeed{}
+
+// Fetch returns every row published after since.
+func (c CSVFeed) Fetch(ctx context.Context, since time.Time) ([]FeedEntry, string, error) {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	var entries []FeedEntry
+	lastPublishedAt := since.Unix()
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if len(row) != 2 {
+			return nil, "", errors.New("malformed CSV feed row")
+		}
+
+		publishedAt, err := strconv.ParseInt(row[1], 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		if publishedAt <= since.Unix() {
+			continue
+		}
+
+		entry, err := parseFullHashLine(row[0])
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, entry)
+		if publishedAt > lastPublishedAt {
+			lastPublishedAt = publishedAt
+		}
+	}
+	return entries, strconv.FormatInt(lastPublishedAt, 10), nil
+}
+
+// ConfirmFullHash re-scans the feed file looking for an exact hash match.
+func (c CSVFeed) ConfirmFullHash(prefix [4]byte, fullHash [32]byte) (bool, error) {
+	entries, _, err := c.Fetch(context.Background(), time.Time{})
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.FullHash == fullHash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewCSVFeed creates a CSVFeed backed by the file at path.
+func NewCSVFeed(path string) CSVFeed {
+	return CSVFeed{path: path}
+}
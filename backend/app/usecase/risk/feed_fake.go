@@ -0,0 +1,38 @@
+package risk
+
+import (
+	"context"
+	"time"
+)
+
+// FeedFake is an in-memory Feed for tests. It records every `since` value
+// it was called with, so tests can assert that a sync resumed from the
+// right point.
+type FeedFake struct {
+	entries       []FeedEntry
+	cursor        string
+	confirmedHash map[[32]byte]bool
+	sinceCalls    *[]time.Time
+}
+
+var _ Feed = FeedFake{}
+
+// Fetch returns every entry the fake was seeded with and records since.
+func (f FeedFake) Fetch(ctx context.Context, since time.Time) ([]FeedEntry, string, error) {
+	if f.sinceCalls != nil {
+		*f.sinceCalls = append(*f.sinceCalls, since)
+	}
+	return f.entries, f.cursor, nil
+}
+
+// ConfirmFullHash reports whether fullHash was seeded as confirmed.
+func (f FeedFake) ConfirmFullHash(prefix [4]byte, fullHash [32]byte) (bool, error) {
+	return f.confirmedHash[fullHash], nil
+}
+
+// NewFeedFake creates a FeedFake that returns entries and cursor from
+// Fetch, confirming only the hashes in confirmedHash and recording every
+// `since` argument it receives into sinceCalls.
+func NewFeedFake(entries []FeedEntry, cursor string, confirmedHash map[[32]byte]bool, sinceCalls *[]time.Time) FeedFake {
+	return FeedFake{entries: entries, cursor: cursor, confirmedHash: confirmedHash, sinceCalls: sinceCalls}
+}
@@ -0,0 +1,88 @@
+package risk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type httpFeedResponse struct {
+	Entries []httpFeedEntry `json:"entries"`
+	Cursor  string          `json:"cursor"`
+}
+
+type httpFeedEntry struct {
+	FullHash string `json:"full_hash"`
+}
+
+// HTTPFeed pulls reputation data from a JSON HTTP endpoint that accepts a
+// `since` unix-seconds query parameter and returns entries newer than it,
+// along with an opaque cursor.
+type HTTPFeed struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ Feed = HTTPFeed{}
+
+// Fetch requests every entry published after since.
+func (h HTTPFeed) Fetch(ctx context.Context, since time.Time) ([]FeedEntry, string, error) {
+	url := fmt.Sprintf("%s?since=%d", h.baseURL, since.Unix())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var decoded httpFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, "", err
+	}
+
+	entries := make([]FeedEntry, 0, len(decoded.Entries))
+	for _, raw := range decoded.Entries {
+		entry, err := parseFullHashLine(raw.FullHash)
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, decoded.Cursor, nil
+}
+
+// ConfirmFullHash asks the remote feed to confirm a full hash match,
+// keeping the full hash list off this process.
+func (h HTTPFeed) ConfirmFullHash(prefix [4]byte, fullHash [32]byte) (bool, error) {
+	url := fmt.Sprintf("%s/confirm?prefix=%s&hash=%s", h.baseURL, hex.EncodeToString(prefix[:]), hex.EncodeToString(fullHash[:]))
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var decoded struct {
+		Confirmed bool `json:"confirmed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return false, err
+	}
+	return decoded.Confirmed, nil
+}
+
+// NewHTTPFeed creates an HTTPFeed polling baseURL with httpClient.
+func NewHTTPFeed(baseURL string, httpClient *http.Client) HTTPFeed {
+	return HTTPFeed{baseURL: baseURL, httpClient: httpClient}
+}
@@ -0,0 +1,85 @@
+package risk
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalFileFeed reads newline-delimited full SHA-256 hashes (hex-encoded)
+// from a file on disk. It has no notion of "since" and always returns its
+// entire contents; the cursor is the number of non-empty lines read.
+type LocalFileFeed struct {
+	path string
+}
+
+var _ Feed = LocalFileFeed{}
+
+// Fetch reads every hash in the feed file.
+func (l LocalFileFeed) Fetch(ctx context.Context, since time.Time) ([]FeedEntry, string, error) {
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	var entries []FeedEntry
+	lineCount := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lineCount++
+
+		entry, err := parseFullHashLine(line)
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return entries, strconv.Itoa(lineCount), nil
+}
+
+// ConfirmFullHash re-scans the feed file looking for an exact hash match.
+func (l LocalFileFeed) ConfirmFullHash(prefix [4]byte, fullHash [32]byte) (bool, error) {
+	entries, _, err := l.Fetch(context.Background(), time.Time{})
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.FullHash == fullHash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parseFullHashLine(line string) (FeedEntry, error) {
+	raw, err := hex.DecodeString(line)
+	if err != nil {
+		return FeedEntry{}, err
+	}
+	if len(raw) != 32 {
+		return FeedEntry{}, errors.New("full hash must be 32 bytes")
+	}
+	var fullHash [32]byte
+	copy(fullHash[:], raw)
+	var prefix [4]byte
+	copy(prefix[:], raw[:4])
+	return FeedEntry{HashPrefix: prefix, FullHash: fullHash}, nil
+}
+
+// NewLocalFileFeed creates a LocalFileFeed backed by the file at path.
+func NewLocalFileFeed(path string) LocalFileFeed {
+	return LocalFileFeed{path: path}
+}
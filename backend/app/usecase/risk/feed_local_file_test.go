@@ -0,0 +1,25 @@
+// +build !integration all
+
+package risk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+)
+
+func TestLocalFileFeed_Fetch_RejectsShortHash(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "feed.txt")
+	err := os.WriteFile(path, []byte("deadbeef\n"), 0600)
+	assert.Equal(t, nil, err)
+
+	feed := NewLocalFileFeed(path)
+	_, _, err = feed.Fetch(context.Background(), time.Time{})
+	assert.NotEqual(t, nil, err)
+}
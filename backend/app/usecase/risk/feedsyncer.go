@@ -0,0 +1,64 @@
+package risk
+
+import (
+	"context"
+
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// FeedSyncer pulls reputation data from a Feed on each Sync call and
+// merges the hash prefixes it publishes into a PrefixSet. It persists the
+// feed's cursor together with the time of the sync, and the next Sync,
+// including one after a restart, fetches only what was published since
+// that time. FeedSyncer does not schedule Sync calls itself.
+type FeedSyncer struct {
+	feedName      string
+	feed          Feed
+	feedStateRepo repository.FeedStateRepo
+	prefixSet     *PrefixSet
+	timer         timer.Timer
+}
+
+// Sync pulls every entry published since the last sync and merges their
+// hash prefixes into the syncer's PrefixSet.
+func (f FeedSyncer) Sync(ctx context.Context) error {
+	state, err := f.feedStateRepo.GetFeedState(f.feedName)
+	if err != nil {
+		return err
+	}
+
+	entries, cursor, err := f.feed.Fetch(ctx, state.SyncedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		f.prefixSet.Add(entry.HashPrefix)
+	}
+
+	return f.feedStateRepo.SaveFeedState(entity.FeedState{
+		FeedName: f.feedName,
+		Cursor:   cursor,
+		SyncedAt: f.timer.Now(),
+	})
+}
+
+// NewFeedSyncer creates a FeedSyncer that syncs feed into prefixSet,
+// tracking progress under feedName in feedStateRepo.
+func NewFeedSyncer(
+	feedName string,
+	feed Feed,
+	feedStateRepo repository.FeedStateRepo,
+	prefixSet *PrefixSet,
+	tm timer.Timer,
+) FeedSyncer {
+	return FeedSyncer{
+		feedName:      feedName,
+		feed:          feed,
+		feedStateRepo: feedStateRepo,
+		prefixSet:     prefixSet,
+		timer:         tm,
+	}
+}
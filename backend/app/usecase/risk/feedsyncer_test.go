@@ -0,0 +1,50 @@
+// +build !integration all
+
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+func TestFeedSyncer_Sync_ResumesFromPersistedState(t *testing.T) {
+	t.Parallel()
+
+	firstSync := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondSync := firstSync.Add(time.Hour)
+
+	var sinceCalls []time.Time
+	entry := FeedEntry{HashPrefix: [4]byte{1, 2, 3, 4}}
+	feed := NewFeedFake([]FeedEntry{entry}, "cursor-1", map[[32]byte]bool{}, &sinceCalls)
+
+	feedStateRepo := repository.NewFeedStateFake(map[string]entity.FeedState{})
+	prefixSet := NewPrefixSet()
+
+	syncer := NewFeedSyncer("test-feed", feed, &feedStateRepo, prefixSet, timer.NewStub(firstSync))
+	err := syncer.Sync(context.Background())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, prefixSet.Has(entry.HashPrefix))
+
+	state, err := feedStateRepo.GetFeedState("test-feed")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "cursor-1", state.Cursor)
+	assert.Equal(t, firstSync, state.SyncedAt)
+
+	syncer = NewFeedSyncer("test-feed", feed, &feedStateRepo, prefixSet, timer.NewStub(secondSync))
+	err = syncer.Sync(context.Background())
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 2, len(sinceCalls))
+	assert.Equal(t, time.Time{}, sinceCalls[0])
+	assert.Equal(t, firstSync, sinceCalls[1])
+
+	state, err = feedStateRepo.GetFeedState("test-feed")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, secondSync, state.SyncedAt)
+}
@@ -0,0 +1,34 @@
+package risk
+
+import "sync"
+
+// PrefixSet is a thread-safe set of 4-byte SHA-256 hash prefixes, kept in
+// memory so lookups never touch disk or network on the hot path.
+type PrefixSet struct {
+	mutex    sync.RWMutex
+	prefixes map[[4]byte]bool
+}
+
+// Add registers prefix as known-risky.
+func (p *PrefixSet) Add(prefix [4]byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.prefixes == nil {
+		p.prefixes = make(map[[4]byte]bool)
+	}
+	p.prefixes[prefix] = true
+}
+
+// Has returns whether prefix has been registered.
+func (p *PrefixSet) Has(prefix [4]byte) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.prefixes[prefix]
+}
+
+// NewPrefixSet creates an empty PrefixSet.
+func NewPrefixSet() *PrefixSet {
+	return &PrefixSet{prefixes: make(map[[4]byte]bool)}
+}
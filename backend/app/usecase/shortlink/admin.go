@@ -0,0 +1,185 @@
+package shortlink
+
+import (
+	"errors"
+
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/authorizer"
+	"github.com/short-d/short/backend/app/usecase/authorizer/rbac/role"
+	"github.com/short-d/short/backend/app/usecase/changelog"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// BulkUpdateItem is a single short link update within a bulk operation.
+type BulkUpdateItem struct {
+	OldAlias string
+	Update   Update
+}
+
+// AdminManager performs operational short link management that requires
+// elevated privileges: bulk edits, ownership transfers, and disabling
+// links, all gated on the caller holding role.Admin.
+type AdminManager struct {
+	shortLinkRepo      repository.ShortLinkRepo
+	shortLinkAliasRepo repository.ShortLinkAliasRepo
+	userShortLinkRepo  repository.UserShortLinkRepo
+	updater            UpdaterPersist
+	authorizer         authorizer.Authorizer
+	changeLog          changelog.Persist
+}
+
+// bulkApplied records everything a single BulkUpdateItem changed, so
+// atomic mode can undo it in full if a later item in the same batch
+// fails.
+type bulkApplied struct {
+	original       entity.ShortLink
+	addedAliases   []string
+	removedAliases []entity.ShortLinkAlias
+	changeID       string
+}
+
+// BulkUpdateShortLinks applies items in order. When atomic is true, any
+// failure rolls back every update already applied in this call, including
+// secondary alias changes and changelog entries, and the whole batch
+// fails together; when atomic is false, failed items are skipped and the
+// rest are still applied (best effort).
+func (a AdminManager) BulkUpdateShortLinks(requesterID string, items []BulkUpdateItem, atomic bool) ([]entity.ShortLink, error) {
+	if err := a.authorizer.IsAuthorized(requesterID, role.Admin); err != nil {
+		return nil, err
+	}
+
+	var applied []entity.ShortLink
+	var appliedState []bulkApplied
+	for _, item := range items {
+		original, err := a.shortLinkRepo.GetShortLinkByAlias(item.OldAlias)
+		if err != nil {
+			if atomic {
+				a.rollback(appliedState)
+				return nil, err
+			}
+			continue
+		}
+
+		var removedAliases []entity.ShortLinkAlias
+		for _, alias := range item.Update.RemoveAliases {
+			row, err := a.shortLinkAliasRepo.GetAlias(alias)
+			if err == nil {
+				removedAliases = append(removedAliases, row)
+			}
+		}
+
+		updated, err := a.updater.UpdateShortLink(item.OldAlias, item.Update)
+		if err != nil {
+			if atomic {
+				a.rollback(appliedState)
+				return nil, err
+			}
+			continue
+		}
+
+		changeID, err := a.changeLog.Log(requesterID, "bulk updated short link "+item.OldAlias)
+		if err != nil {
+			current := bulkApplied{
+				original:       original,
+				addedAliases:   item.Update.AddAliases,
+				removedAliases: removedAliases,
+			}
+			if atomic {
+				a.rollback(append(appliedState, current))
+				return nil, err
+			}
+			a.rollback([]bulkApplied{current})
+			continue
+		}
+
+		applied = append(applied, updated)
+		appliedState = append(appliedState, bulkApplied{
+			original:       original,
+			addedAliases:   item.Update.AddAliases,
+			removedAliases: removedAliases,
+			changeID:       changeID,
+		})
+	}
+	return applied, nil
+}
+
+// rollback undoes every change recorded in appliedState: it deletes
+// aliases added, recreates aliases removed, restores each canonical row
+// to its pre-update state, and erases the changelog entry written for it.
+func (a AdminManager) rollback(appliedState []bulkApplied) {
+	for _, state := range appliedState {
+		for _, alias := range state.addedAliases {
+			_ = a.shortLinkAliasRepo.DeleteAlias(alias)
+		}
+		for _, removed := range state.removedAliases {
+			_ = a.shortLinkAliasRepo.CreateAlias(removed)
+		}
+		_ = a.shortLinkRepo.UpdateShortLink(state.original)
+		_ = a.changeLog.Delete(state.changeID)
+	}
+}
+
+// TransferShortLink re-assigns alias from fromUserID to toUser, failing if
+// fromUserID does not currently own it.
+func (a AdminManager) TransferShortLink(requesterID string, alias string, fromUserID string, toUser entity.User) error {
+	if err := a.authorizer.IsAuthorized(requesterID, role.Admin); err != nil {
+		return err
+	}
+
+	owner, err := a.userShortLinkRepo.GetOwner(alias)
+	if err != nil {
+		return err
+	}
+	if owner.ID != fromUserID {
+		return errors.New("fromUserID does not own this short link")
+	}
+
+	err = a.userShortLinkRepo.TransferOwnership(alias, toUser)
+	if err != nil {
+		return err
+	}
+	_, err = a.changeLog.Log(requesterID, "transferred short link "+alias+" to "+toUser.ID)
+	return err
+}
+
+// DisableShortLink marks alias as disabled, recording reason, e.g. when
+// taking down a link flagged as part of a phishing campaign.
+func (a AdminManager) DisableShortLink(requesterID string, alias string, reason string) error {
+	if err := a.authorizer.IsAuthorized(requesterID, role.Admin); err != nil {
+		return err
+	}
+
+	shortLink, err := a.shortLinkRepo.GetShortLinkByAlias(alias)
+	if err != nil {
+		return err
+	}
+	shortLink.Disabled = true
+	shortLink.DisabledReason = reason
+
+	err = a.shortLinkRepo.UpdateShortLink(shortLink)
+	if err != nil {
+		return err
+	}
+	_, err = a.changeLog.Log(requesterID, "disabled short link "+alias+": "+reason)
+	return err
+}
+
+// NewAdminManager creates an AdminManager backed by the given
+// collaborators.
+func NewAdminManager(
+	shortLinkRepo repository.ShortLinkRepo,
+	shortLinkAliasRepo repository.ShortLinkAliasRepo,
+	userShortLinkRepo repository.UserShortLinkRepo,
+	updater UpdaterPersist,
+	au authorizer.Authorizer,
+	changeLog changelog.Persist,
+) AdminManager {
+	return AdminManager{
+		shortLinkRepo:      shortLinkRepo,
+		shortLinkAliasRepo: shortLinkAliasRepo,
+		userShortLinkRepo:  userShortLinkRepo,
+		updater:            updater,
+		authorizer:         au,
+		changeLog:          changeLog,
+	}
+}
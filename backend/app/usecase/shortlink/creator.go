@@ -0,0 +1,126 @@
+package shortlink
+
+import (
+	"errors"
+
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/keygen"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/risk"
+	"github.com/short-d/short/backend/app/usecase/validator"
+)
+
+// Creator creates new short links on behalf of a user.
+type Creator interface {
+	CreateShortLink(shortLink entity.ShortLink, user entity.User) (entity.ShortLink, error)
+}
+
+// CreatorPersist is a Creator that persists short links to a repository.
+type CreatorPersist struct {
+	shortLinkRepo      repository.ShortLinkRepo
+	shortLinkAliasRepo repository.ShortLinkAliasRepo
+	userShortLinkRepo  repository.UserShortLinkRepo
+	keyGen             keygen.KeyGenerator
+	longLinkValidator  validator.LongLink
+	aliasValidator     validator.CustomAlias
+	timer              timer.Timer
+	riskDetector       risk.Detector
+}
+
+var _ Creator = (*CreatorPersist)(nil)
+
+// CreateShortLink validates shortLink, rejects malicious long links, and
+// persists a new canonical row owned by user.
+func (c CreatorPersist) CreateShortLink(shortLink entity.ShortLink, user entity.User) (entity.ShortLink, error) {
+	if !c.longLinkValidator.IsValid(shortLink.LongLink) {
+		return entity.ShortLink{}, errors.New("long link is invalid")
+	}
+
+	isMalicious, err := c.riskDetector.IsMalicious(shortLink.LongLink)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	if isMalicious {
+		return entity.ShortLink{}, errors.New("long link is malicious")
+	}
+
+	isURLMalicious, err := c.riskDetector.IsURLMalicious(shortLink.LongLink)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	if isURLMalicious {
+		return entity.ShortLink{}, errors.New("long link is malicious")
+	}
+
+	if shortLink.Alias != "" {
+		if !c.aliasValidator.IsValid(shortLink.Alias) {
+			return entity.ShortLink{}, errors.New("alias is invalid")
+		}
+	} else {
+		key, err := c.keyGen.NewKey()
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+		shortLink.Alias = string(key)
+	}
+
+	isExist, err := c.shortLinkRepo.IsAliasExist(shortLink.Alias)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	if !isExist {
+		isExist, err = c.shortLinkAliasRepo.IsAliasExist(shortLink.Alias)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+	}
+	if isExist {
+		return entity.ShortLink{}, errors.New("alias already exists")
+	}
+
+	id, err := c.keyGen.NewKey()
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	shortLink.ID = string(id)
+
+	now := c.timer.Now()
+	shortLink.CreatedAt = now
+	shortLink.UpdatedAt = now
+
+	err = c.shortLinkRepo.CreateShortLink(shortLink)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+
+	err = c.userShortLinkRepo.CreateUserShortLink(user, shortLink)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	return shortLink, nil
+}
+
+// NewCreatorPersist creates a CreatorPersist backed by the given
+// collaborators.
+func NewCreatorPersist(
+	shortLinkRepo repository.ShortLinkRepo,
+	shortLinkAliasRepo repository.ShortLinkAliasRepo,
+	userShortLinkRepo repository.UserShortLinkRepo,
+	keyGen keygen.KeyGenerator,
+	longLinkValidator validator.LongLink,
+	aliasValidator validator.CustomAlias,
+	tm timer.Timer,
+	riskDetector risk.Detector,
+) CreatorPersist {
+	return CreatorPersist{
+		shortLinkRepo:      shortLinkRepo,
+		shortLinkAliasRepo: shortLinkAliasRepo,
+		userShortLinkRepo:  userShortLinkRepo,
+		keyGen:             keyGen,
+		longLinkValidator:  longLinkValidator,
+		aliasValidator:     aliasValidator,
+		timer:              tm,
+		riskDetector:       riskDetector,
+	}
+}
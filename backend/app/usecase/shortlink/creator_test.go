@@ -0,0 +1,91 @@
+// +build !integration all
+
+package shortlink
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/keygen"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/risk"
+	"github.com/short-d/short/backend/app/usecase/validator"
+)
+
+func TestCreatorPersist_CreateShortLink_AliasCollidesWithSecondaryAlias(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{
+		"VanityAlias": {Alias: "VanityAlias", CanonicalID: "canonical-1"},
+	})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+
+	keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+	keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+	assert.Equal(t, nil, err)
+
+	creator := NewCreatorPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		keyGen,
+		validator.NewLongLink(),
+		validator.NewCustomAlias(),
+		timer.NewStub(now),
+		risk.NewDetector(blacklist),
+	)
+
+	_, err = creator.CreateShortLink(entity.ShortLink{
+		Alias:    "VanityAlias",
+		LongLink: "https://www.google.com/",
+	}, entity.User{ID: "1"})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCreatorPersist_CreateShortLink_BlocksFeedFlaggedURL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	maliciousURL := "http://malware.wicar.org/data/ms14_064_ole_not_xp.html"
+	maliciousHash := sha256.Sum256([]byte("malware.wicar.org/data/ms14_064_ole_not_xp.html"))
+	var maliciousPrefix [4]byte
+	copy(maliciousPrefix[:], maliciousHash[:4])
+
+	prefixSet := risk.NewPrefixSet()
+	prefixSet.Add(maliciousPrefix)
+	feed := risk.NewFeedFake(nil, "", map[[32]byte]bool{maliciousHash: true}, nil)
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+	riskDetector := risk.NewDetectorWithFeedSync(blacklist, prefixSet, feed)
+
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+
+	keyFetcher := keygen.NewKeyFetcherFake([]keygen.Key{})
+	keyGen, err := keygen.NewKeyGenerator(2, &keyFetcher)
+	assert.Equal(t, nil, err)
+
+	creator := NewCreatorPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		keyGen,
+		validator.NewLongLink(),
+		validator.NewCustomAlias(),
+		timer.NewStub(now),
+		riskDetector,
+	)
+
+	_, err = creator.CreateShortLink(entity.ShortLink{
+		Alias:    "SimpleAlias",
+		LongLink: maliciousURL,
+	}, entity.User{ID: "1"})
+	assert.NotEqual(t, nil, err)
+}
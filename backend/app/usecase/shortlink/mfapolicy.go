@@ -0,0 +1,36 @@
+package shortlink
+
+import (
+	"time"
+
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+)
+
+// ShortLinkMFAPolicy decides whether a short link is high-value enough
+// that mutating it should require a fresh MFA challenge, e.g. because it
+// is old enough to have accrued trust, or popular enough that hijacking
+// it would affect many users.
+type ShortLinkMFAPolicy struct {
+	minAge         time.Duration
+	clickThreshold int64
+	timer          timer.Timer
+}
+
+// RequiresMFA reports whether shortLink is high-value under this policy.
+func (p ShortLinkMFAPolicy) RequiresMFA(shortLink entity.ShortLink) bool {
+	if p.minAge > 0 && p.timer.Now().Sub(shortLink.CreatedAt) >= p.minAge {
+		return true
+	}
+	if p.clickThreshold > 0 && shortLink.ClickCount >= p.clickThreshold {
+		return true
+	}
+	return false
+}
+
+// NewShortLinkMFAPolicy creates a ShortLinkMFAPolicy flagging short links
+// at least minAge old or with at least clickThreshold clicks. Either
+// threshold can be disabled by passing zero.
+func NewShortLinkMFAPolicy(minAge time.Duration, clickThreshold int64, tm timer.Timer) ShortLinkMFAPolicy {
+	return ShortLinkMFAPolicy{minAge: minAge, clickThreshold: clickThreshold, timer: tm}
+}
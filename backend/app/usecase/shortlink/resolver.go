@@ -0,0 +1,45 @@
+package shortlink
+
+import (
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+// Resolver resolves an alias, canonical or secondary, to the short link a
+// redirect should target.
+type Resolver interface {
+	ResolveShortLink(alias string) (entity.ShortLink, error)
+}
+
+// ResolverPersist is a Resolver backed by a repository.
+type ResolverPersist struct {
+	shortLinkRepo      repository.ShortLinkRepo
+	shortLinkAliasRepo repository.ShortLinkAliasRepo
+}
+
+var _ Resolver = (*ResolverPersist)(nil)
+
+// ResolveShortLink first looks up alias in the alias table: if alias is a
+// secondary alias, it maps to a canonical ID, which is then used to fetch
+// the canonical row. Only when alias is not a secondary alias does it
+// fall back to looking up the canonical row directly, which is how a
+// link's own primary alias resolves.
+func (r ResolverPersist) ResolveShortLink(alias string) (entity.ShortLink, error) {
+	canonicalID, err := r.shortLinkAliasRepo.GetCanonicalID(alias)
+	if err == nil {
+		return r.shortLinkRepo.GetShortLinkByID(canonicalID)
+	}
+	return r.shortLinkRepo.GetShortLinkByAlias(alias)
+}
+
+// NewResolverPersist creates a ResolverPersist backed by the given
+// collaborators.
+func NewResolverPersist(
+	shortLinkRepo repository.ShortLinkRepo,
+	shortLinkAliasRepo repository.ShortLinkAliasRepo,
+) ResolverPersist {
+	return ResolverPersist{
+		shortLinkRepo:      shortLinkRepo,
+		shortLinkAliasRepo: shortLinkAliasRepo,
+	}
+}
@@ -0,0 +1,58 @@
+// +build !integration all
+
+package shortlink
+
+import (
+	"testing"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+)
+
+func TestResolverPersist_ResolveShortLink(t *testing.T) {
+	t.Parallel()
+
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{
+		"SimpleAlias": {ID: "canonical-1", Alias: "SimpleAlias", LongLink: "https://www.google.com/"},
+	})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{
+		"VanityAlias": {Alias: "VanityAlias", CanonicalID: "canonical-1"},
+	})
+	resolver := NewResolverPersist(&shortLinkRepo, &shortLinkAliasRepo)
+
+	testCases := []struct {
+		name     string
+		alias    string
+		hasError bool
+	}{
+		{
+			name:     "canonical alias resolves to the canonical row",
+			alias:    "SimpleAlias",
+			hasError: false,
+		},
+		{
+			name:     "secondary alias resolves to the same canonical row",
+			alias:    "VanityAlias",
+			hasError: false,
+		},
+		{
+			name:     "unknown alias is not found",
+			alias:    "DoesNotExist",
+			hasError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			shortLink, err := resolver.ResolveShortLink(testCase.alias)
+			if testCase.hasError {
+				assert.NotEqual(t, nil, err)
+				return
+			}
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "https://www.google.com/", shortLink.LongLink)
+		})
+	}
+}
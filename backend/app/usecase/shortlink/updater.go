@@ -0,0 +1,195 @@
+package shortlink
+
+import (
+	"errors"
+
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/risk"
+	"github.com/short-d/short/backend/app/usecase/validator"
+)
+
+// Update describes a requested change to an existing canonical short link.
+// Nil fields mean "leave unchanged".
+type Update struct {
+	NewAlias      *string
+	NewLongLink   *string
+	AddAliases    []string
+	RemoveAliases []string
+}
+
+// Updater updates existing short links.
+type Updater interface {
+	UpdateShortLink(oldAlias string, update Update) (entity.ShortLink, error)
+}
+
+// UpdaterPersist is an Updater that persists changes to a repository.
+type UpdaterPersist struct {
+	shortLinkRepo      repository.ShortLinkRepo
+	shortLinkAliasRepo repository.ShortLinkAliasRepo
+	userShortLinkRepo  repository.UserShortLinkRepo
+	longLinkValidator  validator.LongLink
+	aliasValidator     validator.CustomAlias
+	timer              timer.Timer
+	riskDetector       risk.Detector
+}
+
+var _ Updater = (*UpdaterPersist)(nil)
+
+// UpdateShortLink applies update to the canonical row currently known by
+// oldAlias. Every alias in AddAliases and RemoveAliases is validated and
+// checked to exist (or not) before any of them is registered or
+// unregistered, so a single call either applies every add and remove or
+// none of them. Renaming the canonical alias does not affect existing
+// secondary aliases, which continue to resolve to the same row.
+func (u UpdaterPersist) UpdateShortLink(oldAlias string, update Update) (entity.ShortLink, error) {
+	shortLink, err := u.shortLinkRepo.GetShortLinkByAlias(oldAlias)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+
+	if update.NewLongLink != nil {
+		if !u.longLinkValidator.IsValid(*update.NewLongLink) {
+			return entity.ShortLink{}, errors.New("long link is invalid")
+		}
+		isMalicious, err := u.riskDetector.IsMalicious(*update.NewLongLink)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+		if isMalicious {
+			return entity.ShortLink{}, errors.New("long link is malicious")
+		}
+
+		isURLMalicious, err := u.riskDetector.IsURLMalicious(*update.NewLongLink)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+		if isURLMalicious {
+			return entity.ShortLink{}, errors.New("long link is malicious")
+		}
+		shortLink.LongLink = *update.NewLongLink
+	}
+
+	if update.NewAlias != nil {
+		if !u.aliasValidator.IsValid(*update.NewAlias) {
+			return entity.ShortLink{}, errors.New("alias is invalid")
+		}
+		isExist, err := u.shortLinkRepo.IsAliasExist(*update.NewAlias)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+		if !isExist {
+			isExist, err = u.shortLinkAliasRepo.IsAliasExist(*update.NewAlias)
+			if err != nil {
+				return entity.ShortLink{}, err
+			}
+		}
+		if isExist && *update.NewAlias != oldAlias {
+			return entity.ShortLink{}, errors.New("alias already exists")
+		}
+		shortLink.Alias = *update.NewAlias
+	}
+
+	seenAdds := make(map[string]bool, len(update.AddAliases))
+	for _, alias := range update.AddAliases {
+		if seenAdds[alias] {
+			return entity.ShortLink{}, errors.New("alias already exists")
+		}
+		seenAdds[alias] = true
+
+		if !u.aliasValidator.IsValid(alias) {
+			return entity.ShortLink{}, errors.New("alias is invalid")
+		}
+		isExist, err := u.shortLinkRepo.IsAliasExist(alias)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+		if !isExist {
+			isExist, err = u.shortLinkAliasRepo.IsAliasExist(alias)
+			if err != nil {
+				return entity.ShortLink{}, err
+			}
+		}
+		if isExist {
+			return entity.ShortLink{}, errors.New("alias already exists")
+		}
+	}
+
+	seenRemoves := make(map[string]bool, len(update.RemoveAliases))
+	for _, alias := range update.RemoveAliases {
+		if seenRemoves[alias] {
+			return entity.ShortLink{}, errors.New("alias not found")
+		}
+		seenRemoves[alias] = true
+
+		_, err := u.shortLinkAliasRepo.GetAlias(alias)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+	}
+
+	// Every add and remove has been validated above, so the mutations below
+	// cannot fail partway through and leave the call partially applied.
+	for _, alias := range update.AddAliases {
+		err := u.shortLinkAliasRepo.CreateAlias(entity.ShortLinkAlias{
+			Alias:       alias,
+			CanonicalID: shortLink.ID,
+			CreatedAt:   u.timer.Now(),
+		})
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+	}
+
+	for _, alias := range update.RemoveAliases {
+		err := u.shortLinkAliasRepo.DeleteAlias(alias)
+		if err != nil {
+			return entity.ShortLink{}, err
+		}
+	}
+
+	shortLink.UpdatedAt = u.timer.Now()
+
+	err = u.shortLinkRepo.UpdateShortLink(shortLink)
+	if err != nil {
+		return entity.ShortLink{}, err
+	}
+	return shortLink, nil
+}
+
+// DeleteShortLink removes the canonical row known by alias along with
+// every secondary alias that pointed at it.
+func (u UpdaterPersist) DeleteShortLink(alias string) error {
+	shortLink, err := u.shortLinkRepo.GetShortLinkByAlias(alias)
+	if err != nil {
+		return err
+	}
+	err = u.shortLinkAliasRepo.DeleteAliasesByCanonicalID(shortLink.ID)
+	if err != nil {
+		return err
+	}
+	return u.shortLinkRepo.DeleteShortLink(alias)
+}
+
+// NewUpdaterPersist creates an UpdaterPersist backed by the given
+// collaborators.
+func NewUpdaterPersist(
+	shortLinkRepo repository.ShortLinkRepo,
+	shortLinkAliasRepo repository.ShortLinkAliasRepo,
+	userShortLinkRepo repository.UserShortLinkRepo,
+	longLinkValidator validator.LongLink,
+	aliasValidator validator.CustomAlias,
+	tm timer.Timer,
+	riskDetector risk.Detector,
+) UpdaterPersist {
+	return UpdaterPersist{
+		shortLinkRepo:      shortLinkRepo,
+		shortLinkAliasRepo: shortLinkAliasRepo,
+		userShortLinkRepo:  userShortLinkRepo,
+		longLinkValidator:  longLinkValidator,
+		aliasValidator:     aliasValidator,
+		timer:              tm,
+		riskDetector:       riskDetector,
+	}
+}
@@ -0,0 +1,123 @@
+// +build !integration all
+
+package shortlink
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/assert"
+	"github.com/short-d/app/fw/timer"
+	"github.com/short-d/short/backend/app/entity"
+	"github.com/short-d/short/backend/app/usecase/repository"
+	"github.com/short-d/short/backend/app/usecase/risk"
+	"github.com/short-d/short/backend/app/usecase/validator"
+)
+
+func TestUpdaterPersist_DeleteShortLink_CascadesAliases(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{
+		"SimpleAlias": {ID: "canonical-1", Alias: "SimpleAlias", LongLink: "https://www.google.com/"},
+	})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{
+		"VanityAlias": {Alias: "VanityAlias", CanonicalID: "canonical-1"},
+		"LegacyAlias": {Alias: "LegacyAlias", CanonicalID: "canonical-1"},
+	})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+
+	updater := NewUpdaterPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		validator.NewLongLink(),
+		validator.NewCustomAlias(),
+		timer.NewStub(now),
+		risk.NewDetector(blacklist),
+	)
+
+	err := updater.DeleteShortLink("SimpleAlias")
+	assert.Equal(t, nil, err)
+
+	isExist, err := shortLinkAliasRepo.IsAliasExist("VanityAlias")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, isExist)
+
+	isExist, err = shortLinkAliasRepo.IsAliasExist("LegacyAlias")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, isExist)
+}
+
+func TestUpdaterPersist_UpdateShortLink_BlocksFeedFlaggedURL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	maliciousURL := "http://malware.wicar.org/data/ms14_064_ole_not_xp.html"
+	maliciousHash := sha256.Sum256([]byte("malware.wicar.org/data/ms14_064_ole_not_xp.html"))
+	var maliciousPrefix [4]byte
+	copy(maliciousPrefix[:], maliciousHash[:4])
+
+	prefixSet := risk.NewPrefixSet()
+	prefixSet.Add(maliciousPrefix)
+	feed := risk.NewFeedFake(nil, "", map[[32]byte]bool{maliciousHash: true}, nil)
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+	riskDetector := risk.NewDetectorWithFeedSync(blacklist, prefixSet, feed)
+
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{
+		"SimpleAlias": {ID: "canonical-1", Alias: "SimpleAlias", LongLink: "https://www.google.com/"},
+	})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+
+	updater := NewUpdaterPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		validator.NewLongLink(),
+		validator.NewCustomAlias(),
+		timer.NewStub(now),
+		riskDetector,
+	)
+
+	_, err := updater.UpdateShortLink("SimpleAlias", Update{NewLongLink: &maliciousURL})
+	assert.NotEqual(t, nil, err)
+}
+
+// TestUpdaterPersist_UpdateShortLink_AddAliasesAtomic verifies that when
+// one alias in AddAliases collides, none of the other aliases in the same
+// call are left registered.
+func TestUpdaterPersist_UpdateShortLink_AddAliasesAtomic(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	shortLinkRepo := repository.NewShortLinkFake(map[string]entity.ShortLink{
+		"SimpleAlias": {ID: "canonical-1", Alias: "SimpleAlias", LongLink: "https://www.google.com/"},
+	})
+	shortLinkAliasRepo := repository.NewShortLinkAliasFake(map[string]entity.ShortLinkAlias{
+		"TakenAlias": {Alias: "TakenAlias", CanonicalID: "canonical-2"},
+	})
+	userShortLinkRepo := repository.NewUserShortLinkRepoFake(nil, nil)
+	blacklist := risk.NewBlackListFake(map[string]bool{})
+
+	updater := NewUpdaterPersist(
+		&shortLinkRepo,
+		&shortLinkAliasRepo,
+		&userShortLinkRepo,
+		validator.NewLongLink(),
+		validator.NewCustomAlias(),
+		timer.NewStub(now),
+		risk.NewDetector(blacklist),
+	)
+
+	_, err := updater.UpdateShortLink("SimpleAlias", Update{
+		AddAliases: []string{"FreeAlias", "TakenAlias"},
+	})
+	assert.NotEqual(t, nil, err)
+
+	isExist, err := shortLinkAliasRepo.IsAliasExist("FreeAlias")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, isExist)
+}
@@ -0,0 +1,20 @@
+package validator
+
+import "regexp"
+
+var customAliasPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,50}$`)
+
+// CustomAlias validates that a user-supplied alias is safe to use as a
+// short link or secondary alias.
+type CustomAlias struct {
+}
+
+// IsValid returns whether alias is a permitted custom alias.
+func (c CustomAlias) IsValid(alias string) bool {
+	return customAliasPattern.MatchString(alias)
+}
+
+// NewCustomAlias creates a CustomAlias validator.
+func NewCustomAlias() CustomAlias {
+	return CustomAlias{}
+}
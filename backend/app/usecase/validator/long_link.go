@@ -0,0 +1,22 @@
+package validator
+
+import "net/url"
+
+// LongLink validates that a candidate long link is a well-formed,
+// absolute URL.
+type LongLink struct {
+}
+
+// IsValid returns whether longLink is an absolute, well-formed URL.
+func (l LongLink) IsValid(longLink string) bool {
+	u, err := url.Parse(longLink)
+	if err != nil {
+		return false
+	}
+	return u.IsAbs()
+}
+
+// NewLongLink creates a LongLink validator.
+func NewLongLink() LongLink {
+	return LongLink{}
+}